@@ -10,15 +10,22 @@ import (
 	"time"
 
 	"github.com/vogiaan/ticketbottle-inventory/config"
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	"github.com/vogiaan/ticketbottle-inventory/internal/consumers"
 	grpcSvc "github.com/vogiaan/ticketbottle-inventory/internal/delivery/grpc"
 	"github.com/vogiaan/ticketbottle-inventory/internal/interceptors"
-	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	"github.com/vogiaan/ticketbottle-inventory/internal/metrics"
 	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
 	"github.com/vogiaan/ticketbottle-inventory/internal/workers"
 	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
 	invpb "github.com/vogiaan/ticketbottle-inventory/pkg/grpc/inventory"
 	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/publisher"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/subscriber"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
@@ -37,41 +44,131 @@ func main() {
 		Encoding: cfg.Log.Encoding,
 	})
 
-	db, err := pkgGorm.New(&cfg.Postgres)
+	tp, err := tracing.New(&cfg.Tracing)
+	if err != nil {
+		l.Fatalf(ctx, "Failed to initialize tracing: %v", err)
+	}
+
+	db, err := pkgGorm.Connect(&cfg.Postgres, cfg.Postgres.ConnectMaxAttempts, cfg.Postgres.ConnectRetryInterval)
 	if err != nil {
 		l.Fatalf(ctx, "Failed to initialize database: %v", err)
 	}
 
-	if err := db.AutoMigrate(
-		&models.TicketClass{},
-		&models.Reservation{},
-	); err != nil {
+	mgr, err := newMigrator(db)
+	if err != nil {
+		l.Fatalf(ctx, "Failed to load migrations: %v", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, mgr, os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := migrateOnStart(ctx, l, mgr, cfg.Postgres.MigrateOnStart); err != nil {
 		l.Fatalf(ctx, "Failed to migrate database: %v", err)
 	}
-	l.Info(ctx, "Database tables migrated successfully")
 
 	repo := pkgGorm.NewRepository(db)
+	pub, err := publisher.New(&cfg.Publisher)
+	if err != nil {
+		l.Fatalf(ctx, "Failed to initialize publisher: %v", err)
+	}
+	defer pub.Close()
+
+	sub, err := subscriber.New(&cfg.Subscriber)
+	if err != nil {
+		l.Fatalf(ctx, "Failed to initialize subscriber: %v", err)
+	}
+
+	var elector *workers.LeaderElector
+	if cfg.Leader.Enabled {
+		elector = workers.NewLeaderElector(l, cfg.Postgres.URL, cfg.Leader.HeartbeatInterval, cfg.Leader.LeaseTimeout, cfg.Leader.RetryInterval)
+	}
+	wkrMng := workers.NewWorkerManager(l, elector)
+
+	var avlBus availability.Bus
+	switch cfg.Availability.Bus {
+	case "postgres":
+		pgBus := availability.NewPgBus(l, db.DB, cfg.Postgres.URL, cfg.Availability.NotifyChannel)
+		wkrMng.Register(pgBus)
+		avlBus = pgBus
+	default:
+		avlBus = availability.NewLocalBus()
+	}
+
+	// Only the leader runs expiry, holds taken on the other replicas reach it through Postgres
+	expSig := workers.NewExpirySignal()
+	var expNtf svc.ExpiryNotifier = expSig
+	if cfg.Leader.Enabled {
+		pgExpSig := workers.NewPgExpirySignal(l, db.DB, cfg.Postgres.URL, cfg.Expiry.NotifyChannel, expSig)
+		wkrMng.Register(pgExpSig)
+		expNtf = pgExpSig
+	}
 
-	rsvSvc := svc.NewReservationService(l, repo)
-	tcSvc := svc.NewTicketClassService(l, repo)
+	rsvSvc := svc.NewReservationService(l, repo, avlBus, expNtf)
+	tcSvc := svc.NewTicketClassService(l, repo, avlBus)
+	evSvc := svc.NewEventService(l, repo, avlBus)
+	outSvc := svc.NewOutboxService(l, repo, pub, cfg.Outbox.MaxAttempts, cfg.Outbox.ClaimTimeout)
+	rcSvc := svc.NewReconciliationService(l, repo, avlBus)
 
-	rsvExpWkr := workers.NewReservationExpiryWorker(l, rsvSvc)
+	rsvExpWkr := workers.NewReservationExpiryWorker(l, rsvSvc, expSig, cfg.Expiry.MinInterval, cfg.Expiry.MaxInterval, cfg.Expiry.BatchSize)
+	outRelayWkr := workers.NewOutboxRelayWorker(l, outSvc, cfg.Outbox.RelayInterval, cfg.Outbox.BatchSize)
+	ordEvtCsm := consumers.NewOrderEventConsumer(l, sub, rsvSvc)
 
-	wkrMng := workers.NewWorkerManager(l)
-	wkrMng.Register(rsvExpWkr)
+	if err := wkrMng.Schedule(rsvExpWkr.Job(cfg.Expiry.Timeout)); err != nil {
+		l.Fatalf(ctx, "Failed to schedule reservation expiry: %v", err)
+	}
+	if err := wkrMng.Schedule(outRelayWkr.Job()); err != nil {
+		l.Fatalf(ctx, "Failed to schedule outbox relay: %v", err)
+	}
+	outCleanWkr := workers.NewOutboxCleanupWorker(l, outSvc, cfg.Outbox.CleanupInterval, cfg.Outbox.Retention, cfg.Outbox.BatchSize)
+	if err := wkrMng.Schedule(outCleanWkr.Job()); err != nil {
+		l.Fatalf(ctx, "Failed to schedule outbox cleanup: %v", err)
+	}
+	if cfg.Reconciliation.Enabled {
+		rcWkr := workers.NewReconciliationWorker(l, rcSvc, cfg.Reconciliation.Interval, cfg.Reconciliation.DryRun)
+		if err := wkrMng.Schedule(rcWkr.Job()); err != nil {
+			l.Fatalf(ctx, "Failed to schedule reconciliation: %v", err)
+		}
+	}
+	wkrMng.Register(ordEvtCsm)
+
+	healthSvr := health.NewServer()
+	wkrMng.Register(workers.NewHealthChecker(l, healthSvr, db, wkrMng, cfg.Health.CheckInterval, cfg.Health.CheckTimeout,
+		invpb.InventoryService_ServiceDesc.ServiceName,
+		invpb.InventoryAdminService_ServiceDesc.ServiceName,
+	))
+
+	if cfg.Metrics.Enabled {
+		metrics.RegisterDBPool(db)
+		metrics.RegisterAvailability(l, db.DB)
+		wkrMng.Register(metrics.NewServer(l, cfg.Metrics.Port, cfg.Metrics.Path))
+	}
 	wkrMng.StartAll(ctx)
 
 	// gRPC server
-	grpcSvc := grpcSvc.NewGrpcService(rsvSvc, tcSvc, l)
+	adminSvc := grpcSvc.NewAdminService(wkrMng, l)
+	grpcSvc := grpcSvc.NewGrpcService(rsvSvc, tcSvc, evSvc, avlBus, l)
 	lnr, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRpcPort))
 	if err != nil {
 		l.Fatalf(ctx, "gRPC server failed to listen: %v", err)
 	}
+	// The admin service has no authentication, it only listens on the internal admin port
+	adminLnr, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.AdminGRpcPort))
+	if err != nil {
+		l.Fatalf(ctx, "Admin gRPC server failed to listen: %v", err)
+	}
 
-	grpcSvr := grpc.NewServer(
-		grpc.UnaryInterceptor(interceptors.GrpcLoggingInterceptor(l)),
-	)
+	grpcSvr := newGrpcServer(l)
 	invpb.RegisterInventoryServiceServer(grpcSvr, grpcSvc)
+	healthpb.RegisterHealthServer(grpcSvr, healthSvr)
+
+	adminSvr := newGrpcServer(l)
+	invpb.RegisterInventoryAdminServiceServer(adminSvr, adminSvc)
+	healthpb.RegisterHealthServer(adminSvr, healthSvr)
 
 	go func() {
 		l.Infof(ctx, "gRPC server is listening on port: %d", cfg.Server.GRpcPort)
@@ -79,6 +176,12 @@ func main() {
 			l.Fatalf(ctx, "Failed to serve gRPC: %v", err)
 		}
 	}()
+	go func() {
+		l.Infof(ctx, "Admin gRPC server is listening on port: %d", cfg.Server.AdminGRpcPort)
+		if err := adminSvr.Serve(adminLnr); err != nil {
+			l.Fatalf(ctx, "Failed to serve admin gRPC: %v", err)
+		}
+	}()
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -86,10 +189,39 @@ func main() {
 
 	l.Info(ctx, "Server shutting down...")
 
+	// Report NOT_SERVING first so probes take the replica out of rotation before it stops accepting requests
+	healthSvr.Shutdown()
+	time.Sleep(cfg.Health.ShutdownDelay)
+
 	cancel()
 	time.Sleep(1 * time.Second)
+	// End WatchAvailability streams, GracefulStop waits for them otherwise
+	avlBus.Close()
 	grpcSvr.GracefulStop()
+	adminSvr.GracefulStop()
 	wkrMng.StopAll(ctx)
 
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer flushCancel()
+	if err := tp.Shutdown(flushCtx); err != nil {
+		l.Errorf(ctx, "Failed to flush traces: %v", err)
+	}
+
 	l.Info(ctx, "Server exited")
 }
+
+// newGrpcServer creates a server with the tracing, logging and metrics interceptors
+func newGrpcServer(l pkgLog.Logger) *grpc.Server {
+	return grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptors.GrpcTracingInterceptor(),
+			interceptors.GrpcLoggingInterceptor(l),
+			interceptors.GrpcMetricsInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptors.GrpcStreamTracingInterceptor(),
+			interceptors.GrpcStreamLoggingInterceptor(l),
+			interceptors.GrpcStreamMetricsInterceptor(),
+		),
+	)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/migrations"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/migrate"
+)
+
+const migrateUsage = `usage: api migrate <command>
+
+commands:
+  up            apply all pending migrations
+  down [n]      revert the last n applied migrations (default 1)
+  status        list migrations and whether they are applied
+  to <version>  migrate up or down to the given version, 0 reverts everything`
+
+func newMigrator(db *pkgGorm.DB) (*migrate.Migrator, error) {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(sqlDB, migrations.FS)
+}
+
+// migrateOnStart applies pending migrations, or only checks that none are pending when apply is off
+func migrateOnStart(ctx context.Context, l pkgLog.Logger, mgr *migrate.Migrator, apply bool) error {
+	if !apply {
+		pending, err := mgr.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return fmt.Errorf("%d migrations are pending, run `migrate up` first", pending)
+		}
+		return nil
+	}
+
+	done, err := mgr.Up(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, mg := range done {
+		l.Infof(ctx, "Applied migration %d_%s", mg.Version, mg.Name)
+	}
+	l.Infof(ctx, "Database schema is at version %d", mgr.Latest())
+	return nil
+}
+
+func runMigrate(ctx context.Context, mgr *migrate.Migrator, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := mgr.Up(ctx)
+		printMigrations("applied", done)
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+			steps = n
+		}
+		done, err := mgr.Down(ctx, steps)
+		printMigrations("reverted", done)
+		return err
+
+	case "to":
+		if len(args) < 2 {
+			return fmt.Errorf("missing version\n%s", migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version: %s", args[1])
+		}
+		done, err := mgr.To(ctx, version)
+		printMigrations("migrated", done)
+		return err
+
+	case "status":
+		sts, err := mgr.Status(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED AT")
+		for _, st := range sts {
+			at := "pending"
+			if st.AppliedAt != nil {
+				at = st.AppliedAt.UTC().Format(time.RFC3339)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\n", st.Version, st.Name, at)
+		}
+		return tw.Flush()
+
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], migrateUsage)
+	}
+}
+
+func printMigrations(verb string, done []migrate.Migration) {
+	if len(done) == 0 {
+		fmt.Println("nothing to do")
+		return
+	}
+	for _, mg := range done {
+		fmt.Printf("%s %d_%s\n", verb, mg.Version, mg.Name)
+	}
+}
@@ -0,0 +1,338 @@
+// Command stress runs a concurrent mix of holds, confirms, releases and expiry sweeps against a
+// real database and reports deadlocks and counter drift. Transaction retries are disabled so every
+// deadlock Postgres detects is surfaced instead of being absorbed by WithTransaction.
+//
+//	go run ./cmd/stress -workers 32 -classes 8 -duration 30s
+//
+// TestStress runs a shorter workload in a throwaway schema when INVENTORY_TEST_DATABASE_URL is set.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/config"
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	"github.com/vogiaan/ticketbottle-inventory/internal/migrations"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+	"github.com/vogiaan/ticketbottle-inventory/internal/workers"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/migrate"
+)
+
+type op string
+
+const (
+	opReserve op = "reserve"
+	opConfirm op = "confirm"
+	opRelease op = "release"
+	opExpire  op = "expire"
+)
+
+type opStats struct {
+	ok        atomic.Int64
+	failed    atomic.Int64
+	deadlocks atomic.Int64
+}
+
+type harness struct {
+	rsvSvc  svc.ReservationService
+	tcIDs   []int64
+	eventID string
+
+	mu     sync.Mutex
+	orders []string
+	seq    atomic.Int64
+
+	stats map[op]*opStats
+}
+
+// options are the knobs of one stress run, main fills them from flags
+type options struct {
+	workers  int
+	classes  int
+	duration time.Duration
+	// keep leaves the generated ticket classes and reservations in the database
+	keep bool
+}
+
+// result is what a stress run found, any deadlock or drift fails it
+type result struct {
+	deadlocks int64
+	drift     int
+}
+
+func main() {
+	var opts options
+	flag.IntVar(&opts.workers, "workers", 32, "number of concurrent workers")
+	flag.IntVar(&opts.classes, "classes", 8, "number of ticket classes to spread the load over")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "how long to run the workload")
+	flag.BoolVar(&opts.keep, "keep", false, "keep the generated ticket classes and reservations")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	cfg.Postgres.TxMaxAttempts = 1
+
+	l := pkgLog.InitializeZapLogger(pkgLog.ZapConfig{
+		Level:    "fatal",
+		Mode:     cfg.Log.Mode,
+		Encoding: cfg.Log.Encoding,
+	})
+
+	db, err := pkgGorm.New(&cfg.Postgres)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize database: %v\n", err)
+		os.Exit(1)
+	}
+
+	res, err := runStress(context.Background(), l, db, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if res.deadlocks > 0 || res.drift > 0 {
+		fmt.Printf("FAIL: %d deadlocks, %d ticket classes drifted\n", res.deadlocks, res.drift)
+		os.Exit(1)
+	}
+	fmt.Println("OK: no deadlocks, counters match reservations")
+}
+
+// runStress migrates the database, runs the workload for opts.duration and checks the counters.
+// db must not retry transactions, see PostgresConfig.TxMaxAttempts.
+func runStress(ctx context.Context, l pkgLog.Logger, db *pkgGorm.DB, opts options) (result, error) {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return result{}, fmt.Errorf("failed to get database instance: %w", err)
+	}
+
+	mgr, err := migrate.New(sqlDB, migrations.FS)
+	if err != nil {
+		return result{}, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	if _, err := mgr.Up(ctx); err != nil {
+		return result{}, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	repo := pkgGorm.NewRepository(db)
+	bus := availability.NewLocalBus()
+	defer bus.Close()
+
+	h := &harness{
+		rsvSvc:  svc.NewReservationService(l, repo, bus, workers.NewExpirySignal()),
+		eventID: fmt.Sprintf("stress-%d", time.Now().UnixNano()),
+		stats: map[op]*opStats{
+			opReserve: {},
+			opConfirm: {},
+			opRelease: {},
+			opExpire:  {},
+		},
+	}
+
+	ins := make([]svc.CreateTicketClassInput, opts.classes)
+	for i := range ins {
+		ins[i] = svc.CreateTicketClassInput{
+			EventID:  h.eventID,
+			Name:     fmt.Sprintf("class-%d", i),
+			Currency: "USD",
+			Total:    1_000_000,
+		}
+	}
+
+	tcs, err := svc.NewTicketClassService(l, repo, bus).BatchCreate(ctx, ins)
+	if err != nil {
+		return result{}, fmt.Errorf("failed to create ticket classes: %w", err)
+	}
+	for _, tc := range tcs {
+		h.tcIDs = append(h.tcIDs, tc.ID)
+	}
+
+	fmt.Printf("event_id=%s classes=%d workers=%d duration=%s\n", h.eventID, len(h.tcIDs), opts.workers, opts.duration)
+
+	runCtx, cancel := context.WithTimeout(ctx, opts.duration)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for range opts.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for runCtx.Err() == nil {
+				h.step(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Release what is still held so the counters can be checked against the reservations
+	for _, oCode := range h.takeAll() {
+		h.run(opRelease, func() error { return h.rsvSvc.Release(ctx, oCode) })
+	}
+
+	res := result{deadlocks: h.report()}
+	res.drift, err = h.verify(ctx, repo)
+	if err != nil {
+		return result{}, fmt.Errorf("failed to verify counters: %w", err)
+	}
+
+	if !opts.keep {
+		h.cleanup(ctx, repo)
+	}
+
+	return res, nil
+}
+
+// step runs one random operation. Holds touch several classes so confirm, release and expiry
+// all update multiple ticket_class rows in the same transaction.
+func (h *harness) step(ctx context.Context) {
+	switch n := rand.IntN(10); {
+	case n < 4:
+		oCode := fmt.Sprintf("%s-%d", h.eventID, h.seq.Add(1))
+		in := svc.ReserveInput{
+			OrderCode: oCode,
+			Items:     h.randomItems(),
+			ExpiresAt: time.Now().UTC().Add(time.Duration(50+rand.IntN(1950)) * time.Millisecond),
+		}
+		if h.run(opReserve, func() error { return h.rsvSvc.Reserve(ctx, in) }) {
+			h.push(oCode)
+		}
+	case n < 6:
+		if oCode, ok := h.take(); ok {
+			h.run(opConfirm, func() error { return h.rsvSvc.Confirm(ctx, oCode) })
+		}
+	case n < 8:
+		if oCode, ok := h.take(); ok {
+			h.run(opRelease, func() error { return h.rsvSvc.Release(ctx, oCode) })
+		}
+	default:
+		h.run(opExpire, func() error {
+			_, err := h.rsvSvc.BatchExpireReservations(ctx, 100)
+			return err
+		})
+	}
+}
+
+// randomItems picks two or more distinct classes in random order
+func (h *harness) randomItems() []svc.ReserveItem {
+	n := 2 + rand.IntN(max(1, len(h.tcIDs)-1))
+	items := make([]svc.ReserveItem, 0, n)
+	for _, i := range rand.Perm(len(h.tcIDs))[:min(n, len(h.tcIDs))] {
+		items = append(items, svc.ReserveItem{TicketClassID: h.tcIDs[i], Qty: 1 + rand.IntN(4)})
+	}
+	return items
+}
+
+// run records the outcome of fn. Business errors such as an expired hold are expected in a mixed
+// workload, only deadlocks count as failures.
+func (h *harness) run(o op, fn func() error) bool {
+	st := h.stats[o]
+	err := fn()
+	switch {
+	case err == nil:
+		st.ok.Add(1)
+		return true
+	case errors.Is(err, pkgGorm.ErrDeadlockDetected):
+		st.deadlocks.Add(1)
+	default:
+		st.failed.Add(1)
+	}
+	return false
+}
+
+func (h *harness) push(oCode string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.orders = append(h.orders, oCode)
+}
+
+func (h *harness) take() (string, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.orders) == 0 {
+		return "", false
+	}
+	i := rand.IntN(len(h.orders))
+	oCode := h.orders[i]
+	h.orders[i] = h.orders[len(h.orders)-1]
+	h.orders = h.orders[:len(h.orders)-1]
+	return oCode, true
+}
+
+func (h *harness) takeAll() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	orders := h.orders
+	h.orders = nil
+	return orders
+}
+
+func (h *harness) report() int64 {
+	var deadlocks int64
+	fmt.Printf("%-8s %10s %10s %10s\n", "op", "ok", "failed", "deadlocks")
+	for _, o := range []op{opReserve, opConfirm, opRelease, opExpire} {
+		st := h.stats[o]
+		fmt.Printf("%-8s %10d %10d %10d\n", o, st.ok.Load(), st.failed.Load(), st.deadlocks.Load())
+		deadlocks += st.deadlocks.Load()
+	}
+	return deadlocks
+}
+
+// verify compares the reserved and sold counters with the sum of active and confirmed reservations
+func (h *harness) verify(ctx context.Context, repo *pkgGorm.Repository) (int, error) {
+	type row struct {
+		ID           int64
+		Reserved     int
+		Sold         int
+		ActiveQty    int
+		ConfirmedQty int
+	}
+
+	var rows []row
+	if err := repo.WithContext(ctx).Raw(`
+		SELECT tc.id, tc.reserved, tc.sold,
+			COALESCE(SUM(r.qty) FILTER (WHERE r.status = ?), 0) AS active_qty,
+			COALESCE(SUM(r.qty) FILTER (WHERE r.status = ?), 0) AS confirmed_qty
+		FROM ticket_class tc
+		LEFT JOIN reservation r ON r.ticket_class_id = tc.id
+		WHERE tc.id IN ?
+		GROUP BY tc.id
+		ORDER BY tc.id`,
+		models.ReservationStatusActive, models.ReservationStatusConfirmed, h.tcIDs,
+	).Scan(&rows).Error; err != nil {
+		return 0, err
+	}
+
+	drift := 0
+	for _, r := range rows {
+		if r.Reserved != r.ActiveQty || r.Sold != r.ConfirmedQty {
+			drift++
+			fmt.Printf("drift ticket_class_id=%d reserved=%d active_qty=%d sold=%d confirmed_qty=%d\n",
+				r.ID, r.Reserved, r.ActiveQty, r.Sold, r.ConfirmedQty)
+		}
+	}
+	return drift, nil
+}
+
+func (h *harness) cleanup(ctx context.Context, repo *pkgGorm.Repository) {
+	db := repo.WithContext(ctx)
+	if err := db.Unscoped().Where("ticket_class_id IN ?", h.tcIDs).Delete(&models.Reservation{}).Error; err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to delete reservations: %v\n", err)
+	}
+	if err := db.Unscoped().Where("id IN ?", h.tcIDs).Delete(&models.TicketClass{}).Error; err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to delete ticket classes: %v\n", err)
+	}
+}
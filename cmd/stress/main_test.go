@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/config"
+	"github.com/vogiaan/ticketbottle-inventory/internal/testdb"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+// envStressDuration overrides how long TestStress runs, e.g. 2m for a soak run
+const envStressDuration = "INVENTORY_STRESS_DURATION"
+
+func TestStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("stress test skipped in short mode")
+	}
+
+	opts := options{
+		workers:  16,
+		classes:  4,
+		duration: 10 * time.Second,
+	}
+	if v := os.Getenv(envStressDuration); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			t.Fatalf("invalid %s: %v", envStressDuration, err)
+		}
+		opts.duration = d
+	}
+
+	db, err := pkgGorm.New(&config.PostgresConfig{
+		URL:          testdb.URL(t),
+		MaxOpenConns: opts.workers + 4,
+		MaxIdleConns: opts.workers + 4,
+		// Retries would hide the deadlocks the test looks for
+		TxMaxAttempts: 1,
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	res, err := runStress(context.Background(), pkgLog.InitializeTestZapLogger(), db, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.deadlocks > 0 || res.drift > 0 {
+		t.Errorf("%d deadlocks, %d ticket classes drifted", res.deadlocks, res.drift)
+	}
+}
@@ -0,0 +1,183 @@
+package availability
+
+import (
+	"context"
+	"sync"
+)
+
+// Change tells subscribers that the reserved or sold counters of a ticket class moved
+type Change struct {
+	TicketClassID int64
+}
+
+// Publisher is used by the service layer after a transaction that touched inventory commits
+type Publisher interface {
+	Publish(ctx context.Context, changes ...Change)
+}
+
+// Subscriber hands out subscriptions for a set of ticket classes
+type Subscriber interface {
+	Subscribe(tcIDs []int64) *Subscription
+}
+
+type Bus interface {
+	Publisher
+	Subscriber
+	Close()
+}
+
+// LocalBus fans changes out to subscriptions of the same process
+type LocalBus struct {
+	mu     sync.RWMutex
+	nextID int64
+	closed bool
+	subs   map[int64]*Subscription
+}
+
+func NewLocalBus() *LocalBus {
+	return &LocalBus{
+		subs: make(map[int64]*Subscription),
+	}
+}
+
+func (b *LocalBus) Publish(ctx context.Context, changes ...Change) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subs {
+		sub.offer(changes)
+	}
+}
+
+func (b *LocalBus) Subscribe(tcIDs []int64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	sub := newSubscription(b.nextID, tcIDs, b)
+	if b.closed {
+		close(sub.done)
+		return sub
+	}
+	b.subs[sub.id] = sub
+
+	return sub
+}
+
+// Close ends every subscription, streams watching Done return so the server can stop gracefully
+func (b *LocalBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+
+	for id, sub := range b.subs {
+		close(sub.done)
+		delete(b.subs, id)
+	}
+}
+
+// refreshAll marks every watched class as changed, used when changes may have been missed
+func (b *LocalBus) refreshAll() {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subs {
+		changes := make([]Change, 0, len(sub.tcIDs))
+		for tcID := range sub.tcIDs {
+			changes = append(changes, Change{TicketClassID: tcID})
+		}
+		sub.offer(changes)
+	}
+}
+
+func (b *LocalBus) unsubscribe(id int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subs, id)
+}
+
+// Subscription collects changes for its ticket classes. Changes of the same class are coalesced
+// while the receiver is busy, so a slow stream never blocks publishers and never loses a class.
+type Subscription struct {
+	id      int64
+	tcIDs   map[int64]bool
+	bus     *LocalBus
+	mu      sync.Mutex
+	pending map[int64]Change
+	notify  chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func newSubscription(id int64, tcIDs []int64, bus *LocalBus) *Subscription {
+	set := make(map[int64]bool, len(tcIDs))
+	for _, tcID := range tcIDs {
+		set[tcID] = true
+	}
+
+	return &Subscription{
+		id:      id,
+		tcIDs:   set,
+		bus:     bus,
+		pending: make(map[int64]Change),
+		notify:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// C is signalled whenever Drain has something to return
+func (s *Subscription) C() <-chan struct{} {
+	return s.notify
+}
+
+// Done is closed when the bus shuts down
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Drain returns and clears the pending changes
+func (s *Subscription) Drain() []Change {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes := make([]Change, 0, len(s.pending))
+	for _, c := range s.pending {
+		changes = append(changes, c)
+	}
+	s.pending = make(map[int64]Change)
+
+	return changes
+}
+
+// Close detaches the subscription from the bus
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.bus.unsubscribe(s.id)
+	})
+}
+
+func (s *Subscription) offer(changes []Change) {
+	s.mu.Lock()
+	matched := false
+	for _, c := range changes {
+		if s.tcIDs[c.TicketClassID] {
+			s.pending[c.TicketClassID] = c
+			matched = true
+		}
+	}
+	s.mu.Unlock()
+
+	if !matched {
+		return
+	}
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,168 @@
+package availability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	// pg_notify payloads are limited to 8000 bytes, ids are chunked to stay well below it
+	notifyChunkSize    = 300
+	listenRetryMinWait = 500 * time.Millisecond
+	listenRetryMaxWait = 30 * time.Second
+)
+
+// PgBus shares changes between replicas through Postgres LISTEN/NOTIFY. Publish only sends
+// a NOTIFY, local subscriptions are fed by the listener so every replica sees the same stream.
+type PgBus struct {
+	l       pkgLog.Logger
+	db      *gorm.DB
+	url     string
+	channel string
+	local   *LocalBus
+	cancel  context.CancelFunc
+	doneCh  chan struct{}
+
+	mu sync.Mutex
+	// listenErr is why the listener is disconnected, nil while it listens
+	listenErr error
+}
+
+func NewPgBus(l pkgLog.Logger, db *gorm.DB, url, channel string) *PgBus {
+	return &PgBus{
+		l:       l,
+		db:      db,
+		url:     url,
+		channel: channel,
+		local:   NewLocalBus(),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+func (b *PgBus) Publish(ctx context.Context, changes ...Change) {
+	for start := 0; start < len(changes); start += notifyChunkSize {
+		end := min(start+notifyChunkSize, len(changes))
+
+		tcIDs := make([]int64, 0, end-start)
+		for _, c := range changes[start:end] {
+			tcIDs = append(tcIDs, c.TicketClassID)
+		}
+
+		payload, err := json.Marshal(tcIDs)
+		if err != nil {
+			b.l.Errorf(ctx, "availability.PgBus.Publish.Marshal: %v", err)
+			continue
+		}
+
+		if err := b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", b.channel, string(payload)).Error; err != nil {
+			// Other replicas miss this change, but local watchers should still get it
+			b.l.Warnf(ctx, "availability.PgBus.Publish.Notify: %v", err)
+			b.local.Publish(ctx, changes[start:end]...)
+		}
+	}
+}
+
+func (b *PgBus) Subscribe(tcIDs []int64) *Subscription {
+	return b.local.Subscribe(tcIDs)
+}
+
+func (b *PgBus) Close() {
+	b.local.Close()
+}
+
+// Start runs the listener, it implements workers.Worker
+func (b *PgBus) Start(ctx context.Context) {
+	ctx, b.cancel = context.WithCancel(ctx)
+	b.l.Infof(ctx, "Starting availability listener: channel=%s", b.channel)
+
+	go func() {
+		defer close(b.doneCh)
+
+		wait := listenRetryMinWait
+		for ctx.Err() == nil {
+			err := b.listen(ctx)
+			if ctx.Err() != nil {
+				break
+			}
+
+			b.setListenErr(err)
+			b.l.Warnf(ctx, "availability.PgBus.listen: %v, retrying in %v", err, wait)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+			}
+			wait = min(wait*2, listenRetryMaxWait)
+		}
+
+		b.l.Info(ctx, "Availability listener stopped")
+	}()
+}
+
+func (b *PgBus) Stop(ctx context.Context) {
+	if b.cancel != nil {
+		b.cancel()
+		<-b.doneCh
+	}
+	b.l.Info(ctx, "Availability listener shutdown initiated")
+}
+
+// Healthy returns why the listener is disconnected, watchers miss changes from other replicas meanwhile
+func (b *PgBus) Healthy() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.listenErr != nil {
+		return fmt.Errorf("availability listener disconnected: %w", b.listenErr)
+	}
+	return nil
+}
+
+func (b *PgBus) setListenErr(err error) {
+	b.mu.Lock()
+	b.listenErr = err
+	b.mu.Unlock()
+}
+
+// listen holds a dedicated connection, notifications cannot be received through the pool
+func (b *PgBus) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	b.setListenErr(nil)
+
+	// Changes made while we were disconnected are lost, make every watcher reload
+	b.local.refreshAll()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var tcIDs []int64
+		if err := json.Unmarshal([]byte(n.Payload), &tcIDs); err != nil {
+			b.l.Warnf(ctx, "availability.PgBus.listen.Unmarshal: %v", err)
+			continue
+		}
+
+		changes := make([]Change, len(tcIDs))
+		for i, tcID := range tcIDs {
+			changes[i] = Change{TicketClassID: tcID}
+		}
+		b.local.Publish(ctx, changes...)
+	}
+}
@@ -0,0 +1,189 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+	eventpb "github.com/vogiaan/ticketbottle-inventory/pkg/event"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/subscriber"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	minResubscribeWait = 1 * time.Second
+	maxResubscribeWait = 1 * time.Minute
+)
+
+// OrderEventConsumer confirms and releases reservations from order service events.
+// It subscribes again after the subscription failed, until it is stopped.
+type OrderEventConsumer struct {
+	l      pkgLog.Logger
+	sub    subscriber.Subscriber
+	rSvc   svc.ReservationService
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// subErr is the error that ended the last subscription, nil while subscribed
+	subErr error
+}
+
+func NewOrderEventConsumer(
+	l pkgLog.Logger,
+	sub subscriber.Subscriber,
+	rSvc svc.ReservationService,
+) *OrderEventConsumer {
+	return &OrderEventConsumer{
+		l:    l,
+		sub:  sub,
+		rSvc: rSvc,
+	}
+}
+
+func (c *OrderEventConsumer) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.l.Info(ctx, "Starting OrderEventConsumer")
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.subscribeLoop(ctx)
+	}()
+}
+
+// Healthy returns the error that ended the last subscription while the consumer waits to subscribe again
+func (c *OrderEventConsumer) Healthy() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.subErr != nil {
+		return fmt.Errorf("order event subscription stopped: %w", c.subErr)
+	}
+	return nil
+}
+
+// subscribeLoop subscribes until ctx is cancelled, waiting longer after each failure up to maxResubscribeWait.
+// A subscription that lasted longer than that starts the backoff over.
+func (c *OrderEventConsumer) subscribeLoop(ctx context.Context) {
+	wait := minResubscribeWait
+	for {
+		c.setSubErr(nil)
+		started := time.Now()
+
+		err := c.sub.Subscribe(ctx, c.handle, c.handleFailed)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = errors.New("subscription ended")
+		}
+		c.setSubErr(err)
+
+		if time.Since(started) > maxResubscribeWait {
+			wait = minResubscribeWait
+		}
+		c.l.Errorf(ctx, "OrderEventConsumer: subscription stopped, subscribing again in %v: %v", wait, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		wait = min(wait*2, maxResubscribeWait)
+	}
+}
+
+func (c *OrderEventConsumer) setSubErr(err error) {
+	c.mu.Lock()
+	c.subErr = err
+	c.mu.Unlock()
+}
+
+func (c *OrderEventConsumer) Stop(ctx context.Context) {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	if err := c.sub.Close(); err != nil {
+		c.l.Errorf(ctx, "OrderEventConsumer: failed to close subscriber: %v", err)
+	}
+	c.l.Info(ctx, "OrderEventConsumer stopped")
+}
+
+func (c *OrderEventConsumer) handle(ctx context.Context, msg subscriber.Message) error {
+	in, ok, err := c.newProcessOrderEventInput(msg)
+	if err != nil {
+		// A payload we cannot decode will not decode on retry either
+		c.l.Errorf(ctx, "OrderEventConsumer: dropping message %s: %v", msg.ID, err)
+		return nil
+	}
+
+	if !ok {
+		c.l.Debugf(ctx, "OrderEventConsumer: ignoring message %s of type %q", msg.ID, msg.Headers["event_type"])
+		return nil
+	}
+
+	if err := c.rSvc.ProcessOrderEvent(ctx, in); err != nil {
+		c.l.Errorf(ctx, "OrderEventConsumer: failed to process message %s: %v", msg.ID, err)
+		return err
+	}
+
+	return nil
+}
+
+// handleFailed records a message that failed every attempt, so it stops blocking the ones behind it
+func (c *OrderEventConsumer) handleFailed(ctx context.Context, msg subscriber.Message, cause error) error {
+	in, ok, err := c.newProcessOrderEventInput(msg)
+	if err != nil || !ok {
+		// handle never fails for these
+		return nil
+	}
+
+	if err := c.rSvc.RecordFailedOrderEvent(ctx, in, cause); err != nil {
+		c.l.Errorf(ctx, "OrderEventConsumer: failed to record failed message %s: %v", msg.ID, err)
+		return err
+	}
+
+	return nil
+}
+
+// newProcessOrderEventInput decodes msg, ok is false for event types the inventory does not act on
+func (c *OrderEventConsumer) newProcessOrderEventInput(msg subscriber.Message) (svc.ProcessOrderEventInput, bool, error) {
+	in := svc.ProcessOrderEventInput{
+		MessageID: msg.ID,
+		Topic:     msg.Topic,
+		Type:      svc.OrderEventType(msg.Headers["event_type"]),
+	}
+
+	switch in.Type {
+	case svc.OrderEventPaid:
+		var ev eventpb.OrderPaid
+		if err := proto.Unmarshal(msg.Value, &ev); err != nil {
+			return in, false, err
+		}
+		in.OrderCode = ev.GetOrderCode()
+	case svc.OrderEventCancelled:
+		var ev eventpb.OrderCancelled
+		if err := proto.Unmarshal(msg.Value, &ev); err != nil {
+			return in, false, err
+		}
+		in.OrderCode = ev.GetOrderCode()
+	case svc.OrderEventFailed:
+		var ev eventpb.OrderFailed
+		if err := proto.Unmarshal(msg.Value, &ev); err != nil {
+			return in, false, err
+		}
+		in.OrderCode = ev.GetOrderCode()
+	default:
+		return in, false, nil
+	}
+
+	return in, true, nil
+}
@@ -0,0 +1,64 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/subscriber"
+)
+
+// failingSubscriber fails its first subscription and blocks in the later ones
+type failingSubscriber struct {
+	calls chan int
+	n     int
+}
+
+func (s *failingSubscriber) Subscribe(ctx context.Context, h subscriber.Handler, failed subscriber.FailedHandler) error {
+	s.n++
+	s.calls <- s.n
+	if s.n == 1 {
+		return errors.New("broker unreachable")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (s *failingSubscriber) Close() error {
+	return nil
+}
+
+func TestOrderEventConsumerResubscribes(t *testing.T) {
+	sub := &failingSubscriber{calls: make(chan int, 2)}
+	c := NewOrderEventConsumer(pkgLog.InitializeTestZapLogger(), sub, nil)
+
+	ctx := context.Background()
+	c.Start(ctx)
+	t.Cleanup(func() { c.Stop(ctx) })
+
+	<-sub.calls
+	// The failure is reported until the consumer subscribed again
+	deadline := time.Now().Add(time.Second)
+	for c.Healthy() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Healthy() = nil after the subscription failed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case <-sub.calls:
+	case <-time.After(minResubscribeWait + 5*time.Second):
+		t.Fatal("consumer did not subscribe again")
+	}
+
+	deadline = time.Now().Add(time.Second)
+	for c.Healthy() != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("Healthy() = %v after subscribing again", c.Healthy())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
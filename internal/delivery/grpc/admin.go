@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/workers"
+	pkgErrors "github.com/vogiaan/ticketbottle-inventory/pkg/errors"
+	invpb "github.com/vogiaan/ticketbottle-inventory/pkg/grpc/inventory"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/response"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	ErrWorkerNotFound = pkgErrors.NewGRPCError(codes.NotFound, "worker not found")
+	ErrWorkerRunning  = pkgErrors.NewGRPCError(codes.FailedPrecondition, "worker is already running")
+	ErrNotLeader      = pkgErrors.NewGRPCError(codes.FailedPrecondition, "worker only runs on the leader replica, send the request to the leader")
+)
+
+type adminService struct {
+	wkrMng *workers.WorkerManager
+	l      logger.Logger
+	invpb.UnimplementedInventoryAdminServiceServer
+}
+
+func NewAdminService(wkrMng *workers.WorkerManager, l logger.Logger) invpb.InventoryAdminServiceServer {
+	return &adminService{
+		wkrMng: wkrMng,
+		l:      l,
+	}
+}
+
+func (s *adminService) ListWorkers(ctx context.Context, _ *emptypb.Empty) (*invpb.ListWorkersResponse, error) {
+	sts := s.wkrMng.Jobs()
+
+	resp := &invpb.ListWorkersResponse{
+		Workers: make([]*invpb.WorkerStatus, len(sts)),
+		Leader:  s.wkrMng.IsLeader(),
+	}
+	for i, st := range sts {
+		resp.Workers[i] = s.newWorkerStatus(st)
+	}
+
+	return resp, nil
+}
+
+func (s *adminService) TriggerWorker(ctx context.Context, req *invpb.TriggerWorkerRequest) (*invpb.WorkerStatus, error) {
+	if err := s.validateWorkerName(req.GetName()); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.TriggerWorker.validateWorkerName: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	st, err := s.wkrMng.Trigger(ctx, req.GetName())
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.TriggerWorker.Trigger: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	s.l.Infof(ctx, "internal.delivery.grpc.TriggerWorker: %s ran in %v (processed=%d, error=%q)",
+		st.Name, st.LastDuration, st.LastProcessed, st.LastError)
+
+	return s.newWorkerStatus(st), nil
+}
+
+func (s *adminService) PauseWorker(ctx context.Context, req *invpb.PauseWorkerRequest) (*invpb.WorkerStatus, error) {
+	if err := s.validateWorkerName(req.GetName()); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.PauseWorker.validateWorkerName: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	st, err := s.wkrMng.Pause(req.GetName())
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.PauseWorker.Pause: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newWorkerStatus(st), nil
+}
+
+func (s *adminService) ResumeWorker(ctx context.Context, req *invpb.ResumeWorkerRequest) (*invpb.WorkerStatus, error) {
+	if err := s.validateWorkerName(req.GetName()); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.ResumeWorker.validateWorkerName: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	st, err := s.wkrMng.Resume(req.GetName())
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.ResumeWorker.Resume: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newWorkerStatus(st), nil
+}
+
+func (s *adminService) validateWorkerName(name string) error {
+	v := &validator{}
+	v.required("name", name)
+	return v.err()
+}
+
+func (s *adminService) mapError(err error) error {
+	switch {
+	case errors.Is(err, workers.ErrJobNotFound):
+		return ErrWorkerNotFound
+	case errors.Is(err, workers.ErrJobRunning):
+		return ErrWorkerRunning
+	case errors.Is(err, workers.ErrNotLeader):
+		return ErrNotLeader
+	}
+	return pkgErrors.ErrInternal
+}
+
+func (s *adminService) newWorkerStatus(st workers.JobStatus) *invpb.WorkerStatus {
+	pb := &invpb.WorkerStatus{
+		Name:           st.Name,
+		LeaderOnly:     st.LeaderOnly,
+		Active:         st.Active,
+		Running:        st.Running,
+		Paused:         st.Paused,
+		LastDurationMs: st.LastDuration.Milliseconds(),
+		LastError:      st.LastError,
+		LastProcessed:  int32(st.LastProcessed),
+		Runs:           st.Runs,
+		Failures:       st.Failures,
+		Skipped:        st.Skipped,
+	}
+
+	if !st.NextRun.IsZero() {
+		pb.NextRunAt = util.TimeToISO8601Str(st.NextRun)
+	}
+
+	if !st.LastStart.IsZero() {
+		pb.LastRunAt = util.TimeToISO8601Str(st.LastStart)
+		pb.LastResult = "OK"
+		if st.LastError != "" {
+			pb.LastResult = "FAILED"
+		}
+	}
+
+	return pb
+}
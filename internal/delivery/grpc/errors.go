@@ -1,21 +1,92 @@
 package grpc
 
 import (
+	"errors"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/domain"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
 	pkgErrors "github.com/vogiaan/ticketbottle-inventory/pkg/errors"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
 	"google.golang.org/grpc/codes"
 	"gorm.io/gorm"
 )
 
 var (
-	ErrValidationFailed = pkgErrors.NewGRPCError(codes.InvalidArgument, "validation failed")
+	ErrValidationFailed       = pkgErrors.NewGRPCError(codes.InvalidArgument, "validation failed")
+	ErrTicketClassNotFound    = pkgErrors.NewGRPCError(codes.NotFound, "ticket class not found")
+	ErrCapacityBelowCommitted = pkgErrors.NewGRPCError(codes.FailedPrecondition, "total cannot be lower than reserved and sold tickets, use force to oversell")
+	ErrVersionMismatch        = pkgErrors.NewGRPCError(codes.Aborted, "ticket class was modified by another request, reload and retry")
+	ErrTicketClassInUse       = pkgErrors.NewGRPCError(codes.FailedPrecondition, "ticket class has active holds or sales, use force to delete")
+	ErrTicketClassNameTaken   = pkgErrors.NewGRPCError(codes.AlreadyExists, "ticket class name is already used in this event")
+	ErrTicketClassCancelled   = pkgErrors.NewGRPCError(codes.FailedPrecondition, "ticket class was cancelled with its event and cannot be changed")
+	ErrTicketClassNotSellable = pkgErrors.NewGRPCError(codes.FailedPrecondition, "ticket class is not on sale")
+	ErrSalesPaused            = pkgErrors.NewGRPCError(codes.FailedPrecondition, "sales are paused for this event")
+	ErrOutsideSaleWindow      = pkgErrors.NewGRPCError(codes.FailedPrecondition, "ticket class is outside its sale window")
+	ErrOverPerOrderLimit      = pkgErrors.NewGRPCError(codes.FailedPrecondition, "quantity exceeds the per-order limit of the ticket class")
+	ErrReservationNotFound    = pkgErrors.NewGRPCError(codes.NotFound, "no reservations found for order")
+	ErrReservationExists      = pkgErrors.NewGRPCError(codes.AlreadyExists, "order already holds this ticket class")
+	ErrTransactionAborted     = pkgErrors.NewGRPCError(codes.Aborted, "request conflicted with a concurrent update, retry")
+	ErrReservationNotActive   = pkgErrors.NewGRPCError(codes.FailedPrecondition, "reservation is no longer active")
+	ErrReservationExpired     = pkgErrors.NewGRPCError(codes.FailedPrecondition, "reservation has expired")
+	ErrCounterMismatch        = pkgErrors.NewGRPCError(codes.Internal, "ticket class counters are inconsistent")
+	ErrInvariantViolated      = pkgErrors.NewGRPCError(codes.FailedPrecondition, "request would break an inventory invariant")
 )
 
+// domainErrors maps every domain error kind to the gRPC error sent for it
+var domainErrors = map[domain.Kind]*pkgErrors.GRPCError{
+	domain.KindTicketClassNotFound:    ErrTicketClassNotFound,
+	domain.KindTicketClassNotSellable: ErrTicketClassNotSellable,
+	domain.KindTicketClassInUse:       ErrTicketClassInUse,
+	domain.KindTicketClassNameTaken:   ErrTicketClassNameTaken,
+	domain.KindTicketClassCancelled:   ErrTicketClassCancelled,
+	domain.KindCapacityBelowCommitted: ErrCapacityBelowCommitted,
+	domain.KindVersionMismatch:        ErrVersionMismatch,
+	domain.KindInsufficientStock:      pkgErrors.ErrInsufficientStock,
+	domain.KindOutsideSaleWindow:      ErrOutsideSaleWindow,
+	domain.KindOverPerOrderLimit:      ErrOverPerOrderLimit,
+	domain.KindSalesPaused:            ErrSalesPaused,
+	domain.KindReservationNotFound:    ErrReservationNotFound,
+	domain.KindReservationExists:      ErrReservationExists,
+	domain.KindReservationNotActive:   ErrReservationNotActive,
+	domain.KindReservationExpired:     ErrReservationExpired,
+	domain.KindCounterMismatch:        ErrCounterMismatch,
+	domain.KindInvariantViolated:      ErrInvariantViolated,
+}
+
+// checkConstraints maps the CHECK constraints of the schema to the domain error kind they stand for,
+// constraints that are not listed are reported as KindInvariantViolated
+var checkConstraints = map[string]domain.Kind{
+	models.CheckTicketClassCapacity: domain.KindInsufficientStock,
+	models.CheckTicketClassReserved: domain.KindCounterMismatch,
+	models.CheckTicketClassSold:     domain.KindCounterMismatch,
+}
+
 func (s *grpcService) mapError(err error) error {
-	if err == gorm.ErrRecordNotFound {
+	var dErr *domain.Error
+	if errors.As(err, &dErr) {
+		if grpcErr, ok := domainErrors[dErr.Kind]; ok {
+			return grpcErr.WithInfo(string(dErr.Kind), dErr.Metadata)
+		}
+	}
+	var pgErr *pkgGorm.PgError
+	if errors.As(err, &pgErr) && errors.Is(err, pkgGorm.ErrCheckViolation) {
+		kind, ok := checkConstraints[pgErr.Constraint]
+		if !ok {
+			kind = domain.KindInvariantViolated
+		}
+		return domainErrors[kind].WithInfo(string(kind), map[string]string{domain.MetaConstraint: pgErr.Constraint})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return pkgErrors.ErrNotFound
 	}
-	if err == gorm.ErrInvalidData {
-		return pkgErrors.ErrInsufficientStock
+	if errors.Is(err, pkgGorm.ErrUniqueViolation) {
+		return pkgErrors.ErrAlreadyExists
+	}
+	if pkgGorm.IsRetryable(err) {
+		return ErrTransactionAborted
+	}
+	if errors.Is(err, pkgGorm.ErrUnavailable) {
+		return pkgErrors.ErrUnavailable
 	}
 	return pkgErrors.ErrInternal
 }
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vogiaan/ticketbottle-inventory/internal/domain"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/response"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestMapDomainErrors(t *testing.T) {
+	tests := []struct {
+		kind domain.Kind
+		code codes.Code
+	}{
+		{kind: domain.KindTicketClassNotFound, code: codes.NotFound},
+		{kind: domain.KindTicketClassNotSellable, code: codes.FailedPrecondition},
+		{kind: domain.KindTicketClassInUse, code: codes.FailedPrecondition},
+		{kind: domain.KindTicketClassNameTaken, code: codes.AlreadyExists},
+		{kind: domain.KindTicketClassCancelled, code: codes.FailedPrecondition},
+		{kind: domain.KindCapacityBelowCommitted, code: codes.FailedPrecondition},
+		{kind: domain.KindVersionMismatch, code: codes.Aborted},
+		{kind: domain.KindInsufficientStock, code: codes.ResourceExhausted},
+		{kind: domain.KindOutsideSaleWindow, code: codes.FailedPrecondition},
+		{kind: domain.KindOverPerOrderLimit, code: codes.FailedPrecondition},
+		{kind: domain.KindSalesPaused, code: codes.FailedPrecondition},
+		{kind: domain.KindReservationNotFound, code: codes.NotFound},
+		{kind: domain.KindReservationExists, code: codes.AlreadyExists},
+		{kind: domain.KindReservationNotActive, code: codes.FailedPrecondition},
+		{kind: domain.KindReservationExpired, code: codes.FailedPrecondition},
+		{kind: domain.KindCounterMismatch, code: codes.Internal},
+		{kind: domain.KindInvariantViolated, code: codes.FailedPrecondition},
+	}
+
+	if len(tests) != len(domainErrors) {
+		t.Errorf("domainErrors maps %d kinds, the test covers %d", len(domainErrors), len(tests))
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			err := domain.New(tt.kind, "business rule").WithInt(domain.MetaTicketClassID, 42)
+
+			// Services may wrap domain errors, the kind must survive it
+			for _, e := range []error{err, fmt.Errorf("reserve: %w", err)} {
+				assertStatus(t, s.mapError(e), tt.code, string(tt.kind), map[string]string{domain.MetaTicketClassID: "42"})
+			}
+		})
+	}
+}
+
+func TestMapError(t *testing.T) {
+	classified := func(code, constraint string) error {
+		return pkgGorm.Classify(&pgconn.PgError{Code: code, ConstraintName: constraint})
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		code   codes.Code
+		reason string
+		meta   map[string]string
+	}{
+		{name: "capacity check", err: classified("23514", models.CheckTicketClassCapacity), code: codes.ResourceExhausted,
+			reason: string(domain.KindInsufficientStock), meta: map[string]string{domain.MetaConstraint: models.CheckTicketClassCapacity}},
+		{name: "counter check", err: classified("23514", models.CheckTicketClassSold), code: codes.Internal,
+			reason: string(domain.KindCounterMismatch), meta: map[string]string{domain.MetaConstraint: models.CheckTicketClassSold}},
+		{name: "unlisted check", err: classified("23514", "chk_other"), code: codes.FailedPrecondition,
+			reason: string(domain.KindInvariantViolated), meta: map[string]string{domain.MetaConstraint: "chk_other"}},
+		{name: "record not found", err: gorm.ErrRecordNotFound, code: codes.NotFound},
+		{name: "wrapped record not found", err: fmt.Errorf("find: %w", gorm.ErrRecordNotFound), code: codes.NotFound},
+		{name: "unique violation", err: classified("23505", "idx_order_ticket"), code: codes.AlreadyExists},
+		{name: "deadlock", err: classified("40P01", ""), code: codes.Aborted},
+		{name: "serialization failure", err: classified("40001", ""), code: codes.Aborted},
+		{name: "lock not available", err: classified("55P03", ""), code: codes.Aborted},
+		{name: "database unavailable", err: classified("08006", ""), code: codes.Unavailable},
+		{name: "foreign key violation", err: classified("23503", ""), code: codes.Internal},
+		{name: "unknown kind", err: domain.New("SOMETHING_NEW", "new rule"), code: codes.Internal},
+		{name: "unexpected error", err: errors.New("boom"), code: codes.Internal},
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertStatus(t, s.mapError(tt.err), tt.code, tt.reason, tt.meta)
+		})
+	}
+}
+
+// assertStatus converts err like the handlers do and checks the gRPC code and the ErrorInfo detail
+func assertStatus(t *testing.T, err error, code codes.Code, reason string, meta map[string]string) {
+	t.Helper()
+
+	st, ok := status.FromError(response.GrpcError(err))
+	if !ok {
+		t.Fatalf("GrpcError() did not return a status")
+	}
+	if st.Code() != code {
+		t.Errorf("code = %v, want %v", st.Code(), code)
+	}
+
+	var info *errdetails.ErrorInfo
+	for _, d := range st.Details() {
+		if i, ok := d.(*errdetails.ErrorInfo); ok {
+			info = i
+		}
+	}
+
+	if reason == "" {
+		if info != nil {
+			t.Errorf("ErrorInfo reason = %q, want no ErrorInfo", info.GetReason())
+		}
+		return
+	}
+	if info == nil {
+		t.Fatalf("no ErrorInfo, want reason %q", reason)
+	}
+	if info.GetReason() != reason {
+		t.Errorf("ErrorInfo reason = %q, want %q", info.GetReason(), reason)
+	}
+	if !maps.Equal(info.GetMetadata(), meta) {
+		t.Errorf("ErrorInfo metadata = %v, want %v", info.GetMetadata(), meta)
+	}
+}
@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"math"
 	"strconv"
 	"time"
 
@@ -10,17 +11,24 @@ import (
 	"github.com/vogiaan/ticketbottle-inventory/pkg/util"
 )
 
+// availabilityStatusDeleted is the status of the update sent to watchers when a class is deleted
+const availabilityStatusDeleted = "DELETED"
+
 // newTicketClassResponse converts a domain model TicketClass to protobuf TicketClass
 func (s *grpcService) newTicketClassResponse(tc models.TicketClass) *invpb.TicketClass {
 	pbTC := &invpb.TicketClass{
-		Id:         strconv.FormatInt(tc.ID, 10),
-		EventId:    tc.EventID,
-		Name:       tc.Name,
-		PriceCents: tc.PriceCents,
-		Currency:   tc.Currency,
-		Total:      int32(tc.Total),
-		CreatedAt:  util.TimeToISO8601Str(tc.CreatedAt),
-		UpdatedAt:  util.TimeToISO8601Str(tc.UpdatedAt),
+		Id:          strconv.FormatInt(tc.ID, 10),
+		EventId:     tc.EventID,
+		Name:        tc.Name,
+		PriceCents:  tc.PriceCents,
+		Currency:    tc.Currency,
+		Total:       int32(tc.Total),
+		Oversold:    int32(tc.Oversold),
+		MaxPerOrder: int32(tc.MaxPerOrder),
+		Version:     tc.Version,
+		Status:      string(tc.Status),
+		CreatedAt:   util.TimeToISO8601Str(tc.CreatedAt),
+		UpdatedAt:   util.TimeToISO8601Str(tc.UpdatedAt),
 	}
 
 	if tc.SaleStartAt != nil {
@@ -29,6 +37,10 @@ func (s *grpcService) newTicketClassResponse(tc models.TicketClass) *invpb.Ticke
 	if tc.SaleEndAt != nil {
 		pbTC.EndSaleAt = util.TimeToISO8601Str(*tc.SaleEndAt)
 	}
+	if tc.SalesPause != nil {
+		pbTC.SalesPaused = true
+		pbTC.SalesPausedReason = tc.SalesPause.Reason
+	}
 
 	return pbTC
 }
@@ -54,6 +66,13 @@ func (s *grpcService) newFindOneTicketClassResponse(tc models.TicketClass) *invp
 	}
 }
 
+// newRestoreTicketClassResponse builds the RestoreTicketClassResponse
+func (s *grpcService) newRestoreTicketClassResponse(tc models.TicketClass) *invpb.RestoreTicketClassResponse {
+	return &invpb.RestoreTicketClassResponse{
+		TicketClass: s.newTicketClassResponse(tc),
+	}
+}
+
 // newFindManyTicketClassResponse builds the FindManyTicketClassResponse
 func (s *grpcService) newFindManyTicketClassResponse(tcs []models.TicketClass) *invpb.FindManyTicketClassResponse {
 	pbTCs := make([]*invpb.TicketClass, len(tcs))
@@ -66,6 +85,133 @@ func (s *grpcService) newFindManyTicketClassResponse(tcs []models.TicketClass) *
 	}
 }
 
+// newBatchCreateTicketClassesResponse builds the BatchCreateTicketClassesResponse
+func (s *grpcService) newBatchCreateTicketClassesResponse(tcs []models.TicketClass) *invpb.BatchCreateTicketClassesResponse {
+	pbTCs := make([]*invpb.TicketClass, len(tcs))
+	for i, tc := range tcs {
+		pbTCs[i] = s.newTicketClassResponse(tc)
+	}
+
+	return &invpb.BatchCreateTicketClassesResponse{
+		TicketClasses: pbTCs,
+	}
+}
+
+// newCloneEventTicketClassesResponse builds the CloneEventTicketClassesResponse
+func (s *grpcService) newCloneEventTicketClassesResponse(tcs []models.TicketClass) *invpb.CloneEventTicketClassesResponse {
+	pbTCs := make([]*invpb.TicketClass, len(tcs))
+	for i, tc := range tcs {
+		pbTCs[i] = s.newTicketClassResponse(tc)
+	}
+
+	return &invpb.CloneEventTicketClassesResponse{
+		TicketClasses: pbTCs,
+	}
+}
+
+// newCheckAvailabilityResponse builds the CheckAvailabilityResponse
+func (s *grpcService) newCheckAvailabilityResponse(out svc.CheckAvailabilityOutput) *invpb.CheckAvailabilityResponse {
+	items := make([]*invpb.CheckAvailabilityItemResult, len(out.Items))
+	for i, item := range out.Items {
+		items[i] = &invpb.CheckAvailabilityItemResult{
+			TicketClassId:     strconv.FormatInt(item.TicketClassID, 10),
+			RequestedQuantity: int32(item.RequestedQty),
+			AvailableQuantity: int32(item.AvailableQty),
+			Accepted:          item.Accepted,
+			Reason:            string(item.Reason),
+		}
+	}
+
+	return &invpb.CheckAvailabilityResponse{
+		Accept: out.Accept,
+		Items:  items,
+	}
+}
+
+// newGetEventAvailabilityResponse builds the GetEventAvailabilityResponse
+func (s *grpcService) newGetEventAvailabilityResponse(out svc.EventAvailabilityOutput) *invpb.GetEventAvailabilityResponse {
+	pbTCs := make([]*invpb.TicketClassAvailability, len(out.TicketClasses))
+	for i, tc := range out.TicketClasses {
+		pbTC := &invpb.TicketClassAvailability{
+			TicketClassId:     strconv.FormatInt(tc.ID, 10),
+			Name:              tc.Name,
+			Total:             int32(tc.Total),
+			Reserved:          int32(tc.Reserved),
+			Sold:              int32(tc.Sold),
+			AvailableQuantity: int32(tc.AvailableQty()),
+			Status:            string(tc.Status),
+			SaleWindow:        string(tc.SaleWindow(out.At)),
+		}
+
+		if tc.SaleStartAt != nil {
+			pbTC.StartSaleAt = util.TimeToISO8601Str(*tc.SaleStartAt)
+		}
+		if tc.SaleEndAt != nil {
+			pbTC.EndSaleAt = util.TimeToISO8601Str(*tc.SaleEndAt)
+		}
+
+		pbTCs[i] = pbTC
+	}
+
+	resp := &invpb.GetEventAvailabilityResponse{
+		EventId:           out.EventID,
+		TicketClasses:     pbTCs,
+		Total:             clampInt32(out.Total),
+		Reserved:          clampInt32(out.Reserved),
+		Sold:              clampInt32(out.Sold),
+		AvailableQuantity: clampInt32(out.Available),
+		SaleWindow:        string(out.SaleWindow),
+		OnSale:            out.OnSale,
+		CheckedAt:         util.TimeToISO8601Str(out.At),
+	}
+
+	if out.SalesPause != nil {
+		resp.SalesPaused = true
+		resp.SalesPausedReason = out.SalesPause.Reason
+	}
+
+	return resp
+}
+
+// clampInt32 converts an event rollup to the int32 of the API. Summed over many classes it can exceed
+// the range, a saturated count is less misleading than a wrapped one.
+func clampInt32(n int) int32 {
+	return int32(max(min(n, math.MaxInt32), math.MinInt32))
+}
+
+// newAvailabilityUpdate builds the AvailabilityUpdate streamed by WatchAvailability
+func (s *grpcService) newAvailabilityUpdate(tc models.TicketClass) *invpb.AvailabilityUpdate {
+	return &invpb.AvailabilityUpdate{
+		TicketClassId:     strconv.FormatInt(tc.ID, 10),
+		EventId:           tc.EventID,
+		Total:             int32(tc.Total),
+		Reserved:          int32(tc.Reserved),
+		Sold:              int32(tc.Sold),
+		AvailableQuantity: int32(tc.AvailableQty()),
+		Status:            string(tc.Status),
+		UpdatedAt:         util.TimeToISO8601Str(tc.UpdatedAt),
+	}
+}
+
+// newDeletedAvailabilityUpdate tells watchers a class was deleted, there are no counters left to send
+func (s *grpcService) newDeletedAvailabilityUpdate(id int64) *invpb.AvailabilityUpdate {
+	return &invpb.AvailabilityUpdate{
+		TicketClassId: strconv.FormatInt(id, 10),
+		Status:        availabilityStatusDeleted,
+		UpdatedAt:     util.TimeToISO8601Str(time.Now().UTC()),
+	}
+}
+
+// newCancelEventResponse builds the CancelEventResponse
+func (s *grpcService) newCancelEventResponse(out svc.CancelEventOutput) *invpb.CancelEventResponse {
+	return &invpb.CancelEventResponse{
+		TicketClassesFrozen:   int32(out.FrozenTicketClasses),
+		ReservationsCancelled: int32(out.CancelledReservations),
+		QuantityReleased:      int32(out.ReleasedQty),
+		RefundOrderCodes:      out.RefundOrderCodes,
+	}
+}
+
 // parseTime parses ISO8601 time string to *time.Time, returns nil if empty
 func parseTime(s string) (*time.Time, error) {
 	if s == "" {
@@ -98,9 +244,33 @@ func (s *grpcService) newCreateTicketClassInput(req *invpb.CreateTicketClassRequ
 		Total:       int(req.GetTotal()),
 		SaleStartAt: startSaleAt,
 		SaleEndAt:   endSaleAt,
+		MaxPerOrder: int(req.GetMaxPerOrder()),
 	}, nil
 }
 
+// newBatchCreateTicketClassesInput converts every row of the batch to service input
+func (s *grpcService) newBatchCreateTicketClassesInput(req *invpb.BatchCreateTicketClassesRequest) ([]svc.CreateTicketClassInput, error) {
+	ins := make([]svc.CreateTicketClassInput, len(req.GetTicketClasses()))
+	for i, pbTC := range req.GetTicketClasses() {
+		in, err := s.newCreateTicketClassInput(pbTC)
+		if err != nil {
+			return nil, err
+		}
+		ins[i] = in
+	}
+
+	return ins, nil
+}
+
+// newCloneEventTicketClassesInput converts protobuf request to service input
+func (s *grpcService) newCloneEventTicketClassesInput(req *invpb.CloneEventTicketClassesRequest) svc.CloneEventTicketClassesInput {
+	return svc.CloneEventTicketClassesInput{
+		SourceEventID: req.GetSourceEventId(),
+		TargetEventID: req.GetTargetEventId(),
+		TimeShift:     time.Duration(req.GetTimeShiftSeconds()) * time.Second,
+	}
+}
+
 // newUpdateTicketClassInput converts protobuf request to service input
 func (s *grpcService) newUpdateTicketClassInput(req *invpb.UpdateTicketClassRequest) (svc.UpdateTicketClassInput, error) {
 	startSaleAt, err := parseTime(req.GetStartSaleAt())
@@ -115,12 +285,15 @@ func (s *grpcService) newUpdateTicketClassInput(req *invpb.UpdateTicketClassRequ
 
 	priceCents := req.GetPriceCents()
 	return svc.UpdateTicketClassInput{
-		Name:        req.GetName(),
-		PriceCents:  &priceCents,
-		Currency:    req.GetCurrency(),
-		Total:       int(req.GetTotal()),
-		SaleStartAt: startSaleAt,
-		SaleEndAt:   endSaleAt,
+		Name:            req.GetName(),
+		PriceCents:      &priceCents,
+		Currency:        req.GetCurrency(),
+		Total:           int(req.GetTotal()),
+		SaleStartAt:     startSaleAt,
+		SaleEndAt:       endSaleAt,
+		MaxPerOrder:     int(req.GetMaxPerOrder()),
+		Force:           req.GetForce(),
+		ExpectedVersion: req.GetExpectedVersion(),
 	}, nil
 }
 
@@ -144,6 +317,27 @@ func (s *grpcService) newGetManyTicketClassInput(req *invpb.FindManyTicketClassR
 	return in, nil
 }
 
+// newWatchAvailabilityInput converts protobuf WatchAvailability request to the ticket class filter
+func (s *grpcService) newWatchAvailabilityInput(req *invpb.WatchAvailabilityRequest) (svc.GetManyTicketClassInput, error) {
+	in := svc.GetManyTicketClassInput{
+		EventID: req.GetEventId(),
+	}
+
+	if len(req.GetTicketClassIds()) > 0 {
+		ids := make([]int64, len(req.GetTicketClassIds()))
+		for i, idStr := range req.GetTicketClassIds() {
+			id, err := strconv.ParseInt(idStr, 10, 64)
+			if err != nil {
+				return svc.GetManyTicketClassInput{}, err
+			}
+			ids[i] = id
+		}
+		in.IDs = ids
+	}
+
+	return in, nil
+}
+
 // newReserveInput converts protobuf Reserve request to service input
 func (s *grpcService) newReserveInput(req *invpb.ReserveRequest) (svc.ReserveInput, error) {
 	expiresAt, err := util.ParseISO8601(req.GetExpiresAt())
@@ -185,3 +379,11 @@ func (s *grpcService) newCheckAvailabilityInput(req *invpb.CheckAvailabilityRequ
 	}
 	return inputs, nil
 }
+
+// newPauseSalesInput converts protobuf PauseSales request to service input
+func (s *grpcService) newPauseSalesInput(req *invpb.PauseSalesRequest) svc.PauseSalesInput {
+	return svc.PauseSalesInput{
+		EventID: req.GetEventId(),
+		Reason:  req.GetReason(),
+	}
+}
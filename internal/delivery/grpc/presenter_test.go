@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"math"
+	"testing"
+
+	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+)
+
+func TestClampInt32(t *testing.T) {
+	tests := []struct {
+		n    int
+		want int32
+	}{
+		{n: 0, want: 0},
+		{n: 1500, want: 1500},
+		{n: math.MaxInt32, want: math.MaxInt32},
+		{n: math.MaxInt32 + 1, want: math.MaxInt32},
+		{n: 3 * math.MaxInt32, want: math.MaxInt32},
+		{n: math.MinInt32 - 1, want: math.MinInt32},
+	}
+
+	for _, tt := range tests {
+		if got := clampInt32(tt.n); got != tt.want {
+			t.Errorf("clampInt32(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestNewGetEventAvailabilityResponseClampsRollup(t *testing.T) {
+	s := &grpcService{}
+	resp := s.newGetEventAvailabilityResponse(svc.EventAvailabilityOutput{
+		EventID:   "evt-1",
+		Total:     2 * math.MaxInt32,
+		Sold:      math.MaxInt32 + 10,
+		Available: math.MaxInt32 - 10,
+	})
+
+	if resp.GetTotal() != math.MaxInt32 || resp.GetSold() != math.MaxInt32 {
+		t.Errorf("total = %d, sold = %d, want both clamped to %d", resp.GetTotal(), resp.GetSold(), int32(math.MaxInt32))
+	}
+	if resp.GetAvailableQuantity() != math.MaxInt32-10 {
+		t.Errorf("available = %d, want %d", resp.GetAvailableQuantity(), math.MaxInt32-10)
+	}
+}
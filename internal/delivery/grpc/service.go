@@ -4,24 +4,31 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
 	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+	pkgErrors "github.com/vogiaan/ticketbottle-inventory/pkg/errors"
 	invpb "github.com/vogiaan/ticketbottle-inventory/pkg/grpc/inventory"
 	"github.com/vogiaan/ticketbottle-inventory/pkg/logger"
 	"github.com/vogiaan/ticketbottle-inventory/pkg/response"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type grpcService struct {
 	rSvc  svc.ReservationService
 	tcSvc svc.TicketClassService
+	evSvc svc.EventService
+	bus   availability.Subscriber
 	l     logger.Logger
 	invpb.UnimplementedInventoryServiceServer
 }
 
-func NewGrpcService(rSvc svc.ReservationService, tcSvc svc.TicketClassService, l logger.Logger) invpb.InventoryServiceServer {
+func NewGrpcService(rSvc svc.ReservationService, tcSvc svc.TicketClassService, evSvc svc.EventService, bus availability.Subscriber, l logger.Logger) invpb.InventoryServiceServer {
 	return &grpcService{
 		rSvc:  rSvc,
 		tcSvc: tcSvc,
+		evSvc: evSvc,
+		bus:   bus,
 		l:     l,
 	}
 }
@@ -48,6 +55,44 @@ func (s *grpcService) CreateTicketClass(ctx context.Context, req *invpb.CreateTi
 	return s.newCreateTicketClassResponse(tc), nil
 }
 
+func (s *grpcService) BatchCreateTicketClasses(ctx context.Context, req *invpb.BatchCreateTicketClassesRequest) (*invpb.BatchCreateTicketClassesResponse, error) {
+	if err := s.validateBatchCreateTicketClassesRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.BatchCreateTicketClasses.validateBatchCreateTicketClassesRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	ins, err := s.newBatchCreateTicketClassesInput(req)
+	if err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.BatchCreateTicketClasses.newBatchCreateTicketClassesInput: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	tcs, err := s.tcSvc.BatchCreate(ctx, ins)
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.BatchCreateTicketClasses.BatchCreate: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newBatchCreateTicketClassesResponse(tcs), nil
+}
+
+func (s *grpcService) CloneEventTicketClasses(ctx context.Context, req *invpb.CloneEventTicketClassesRequest) (*invpb.CloneEventTicketClassesResponse, error) {
+	if err := s.validateCloneEventTicketClassesRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.CloneEventTicketClasses.validateCloneEventTicketClassesRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	tcs, err := s.tcSvc.CloneEvent(ctx, s.newCloneEventTicketClassesInput(req))
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.CloneEventTicketClasses.CloneEvent: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newCloneEventTicketClassesResponse(tcs), nil
+}
+
 func (s *grpcService) UpdateTicketClass(ctx context.Context, req *invpb.UpdateTicketClassRequest) (*invpb.UpdateTicketClassResponse, error) {
 	if err := s.validateUpdateTicketClassRequest(req); err != nil {
 		s.l.Errorf(ctx, "internal.delivery.grpc.UpdateTicketClass.validateUpdateTicketClassRequest: %v", err)
@@ -132,7 +177,7 @@ func (s *grpcService) DeleteTicketClass(ctx context.Context, req *invpb.DeleteTi
 		return nil, response.GrpcError(ErrValidationFailed)
 	}
 
-	err = s.tcSvc.Delete(ctx, id)
+	err = s.tcSvc.Delete(ctx, id, svc.DeleteTicketClassInput{Force: req.GetForce()})
 	if err != nil {
 		err = s.mapError(err)
 		s.l.Errorf(ctx, "internal.delivery.grpc.DeleteTicketClass.Delete: %v", err)
@@ -142,6 +187,28 @@ func (s *grpcService) DeleteTicketClass(ctx context.Context, req *invpb.DeleteTi
 	return &emptypb.Empty{}, nil
 }
 
+func (s *grpcService) RestoreTicketClass(ctx context.Context, req *invpb.RestoreTicketClassRequest) (*invpb.RestoreTicketClassResponse, error) {
+	if err := s.validateRestoreTicketClassRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.RestoreTicketClass.validateRestoreTicketClassRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	id, err := strconv.ParseInt(req.GetId(), 10, 64)
+	if err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.RestoreTicketClass.ParseInt: %v", err)
+		return nil, response.GrpcError(ErrValidationFailed)
+	}
+
+	tc, err := s.tcSvc.Restore(ctx, id)
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.RestoreTicketClass.Restore: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newRestoreTicketClassResponse(tc), nil
+}
+
 func (s *grpcService) CheckAvailability(ctx context.Context, req *invpb.CheckAvailabilityRequest) (*invpb.CheckAvailabilityResponse, error) {
 	if err := s.validateCheckAvailabilityRequest(req); err != nil {
 		s.l.Errorf(ctx, "internal.delivery.grpc.CheckAvailability.validateCheckAvailabilityRequest: %v", err)
@@ -154,16 +221,14 @@ func (s *grpcService) CheckAvailability(ctx context.Context, req *invpb.CheckAva
 		return nil, response.GrpcError(err)
 	}
 
-	accept, err := s.tcSvc.CheckAvailability(ctx, inputs)
+	out, err := s.tcSvc.CheckAvailability(ctx, inputs)
 	if err != nil {
 		err = s.mapError(err)
 		s.l.Errorf(ctx, "internal.delivery.grpc.CheckAvailability.CheckAvailability: %v", err)
 		return nil, response.GrpcError(err)
 	}
 
-	return &invpb.CheckAvailabilityResponse{
-		Accept: accept,
-	}, nil
+	return s.newCheckAvailabilityResponse(out), nil
 }
 
 func (s *grpcService) GetAvailability(ctx context.Context, req *invpb.GetAvailabilityRequest) (*invpb.GetAvailabilityResponse, error) {
@@ -190,6 +255,111 @@ func (s *grpcService) GetAvailability(ctx context.Context, req *invpb.GetAvailab
 	}, nil
 }
 
+func (s *grpcService) GetEventAvailability(ctx context.Context, req *invpb.GetEventAvailabilityRequest) (*invpb.GetEventAvailabilityResponse, error) {
+	if err := s.validateGetEventAvailabilityRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.GetEventAvailability.validateGetEventAvailabilityRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	out, err := s.evSvc.GetAvailability(ctx, req.GetEventId())
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.GetEventAvailability.GetAvailability: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newGetEventAvailabilityResponse(out), nil
+}
+
+// WatchAvailability sends the current counters of every watched class, then one update per class
+// each time its counters change. Changes that arrive while a send is in progress are coalesced.
+// A deleted class gets an update with only its id and the DELETED status, and a full one again if it is restored.
+func (s *grpcService) WatchAvailability(req *invpb.WatchAvailabilityRequest, stream grpc.ServerStreamingServer[invpb.AvailabilityUpdate]) error {
+	ctx := stream.Context()
+
+	if err := s.validateWatchAvailabilityRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.WatchAvailability.validateWatchAvailabilityRequest: %v", err)
+		return response.GrpcError(err)
+	}
+
+	in, err := s.newWatchAvailabilityInput(req)
+	if err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.WatchAvailability.newWatchAvailabilityInput: %v", err)
+		return response.GrpcError(ErrValidationFailed)
+	}
+
+	tcs, err := s.tcSvc.GetMany(ctx, in)
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.WatchAvailability.GetMany: %v", err)
+		return response.GrpcError(err)
+	}
+
+	if len(tcs) == 0 {
+		return response.GrpcError(pkgErrors.ErrNotFound)
+	}
+
+	tcIDs := make([]int64, len(tcs))
+	for i, tc := range tcs {
+		tcIDs[i] = tc.ID
+	}
+
+	// Subscribe before sending the snapshot so no change between the two is lost
+	sub := s.bus.Subscribe(tcIDs)
+	defer sub.Close()
+
+	for _, tc := range tcs {
+		if err := stream.Send(s.newAvailabilityUpdate(tc)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.Done():
+			return nil
+		case <-sub.C():
+			changes := sub.Drain()
+			if len(changes) == 0 {
+				continue
+			}
+
+			ids := make([]int64, len(changes))
+			for i, c := range changes {
+				ids[i] = c.TicketClassID
+			}
+
+			tcs, err := s.tcSvc.GetMany(ctx, svc.GetManyTicketClassInput{IDs: ids})
+			if err != nil {
+				err = s.mapError(err)
+				s.l.Errorf(ctx, "internal.delivery.grpc.WatchAvailability.GetMany: %v", err)
+				return response.GrpcError(err)
+			}
+
+			found := make(map[int64]bool, len(tcs))
+			for _, tc := range tcs {
+				found[tc.ID] = true
+				if err := stream.Send(s.newAvailabilityUpdate(tc)); err != nil {
+					return err
+				}
+			}
+
+			// GetMany skips deleted classes
+			for _, id := range ids {
+				if found[id] {
+					continue
+				}
+				found[id] = true
+				if err := stream.Send(s.newDeletedAvailabilityUpdate(id)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
 func (s *grpcService) Reserve(ctx context.Context, req *invpb.ReserveRequest) (*emptypb.Empty, error) {
 	if err := s.validateReserveRequest(req); err != nil {
 		s.l.Errorf(ctx, "internal.delivery.grpc.Reserve.validateReserveRequest: %v", err)
@@ -243,3 +413,51 @@ func (s *grpcService) Release(ctx context.Context, req *invpb.ReleaseRequest) (*
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *grpcService) CancelEvent(ctx context.Context, req *invpb.CancelEventRequest) (*invpb.CancelEventResponse, error) {
+	if err := s.validateCancelEventRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.CancelEvent.validateCancelEventRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	out, err := s.evSvc.Cancel(ctx, req.GetEventId())
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.CancelEvent.Cancel: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newCancelEventResponse(out), nil
+}
+
+func (s *grpcService) PauseSales(ctx context.Context, req *invpb.PauseSalesRequest) (*emptypb.Empty, error) {
+	if err := s.validatePauseSalesRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.PauseSales.validatePauseSalesRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	err := s.evSvc.PauseSales(ctx, s.newPauseSalesInput(req))
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.PauseSales.PauseSales: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcService) ResumeSales(ctx context.Context, req *invpb.ResumeSalesRequest) (*emptypb.Empty, error) {
+	if err := s.validateResumeSalesRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.delivery.grpc.ResumeSales.validateResumeSalesRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	err := s.evSvc.ResumeSales(ctx, req.GetEventId())
+	if err != nil {
+		err = s.mapError(err)
+		s.l.Errorf(ctx, "internal.delivery.grpc.ResumeSales.ResumeSales: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
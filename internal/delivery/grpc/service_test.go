@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+	invpb "github.com/vogiaan/ticketbottle-inventory/pkg/grpc/inventory"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"google.golang.org/grpc"
+)
+
+// fakeTicketClassService serves GetMany from a set of live classes, like the service skips deleted ones
+type fakeTicketClassService struct {
+	svc.TicketClassService
+
+	mu   sync.Mutex
+	live map[int64]models.TicketClass
+}
+
+func (f *fakeTicketClassService) GetMany(ctx context.Context, in svc.GetManyTicketClassInput) ([]models.TicketClass, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var tcs []models.TicketClass
+	for _, id := range in.IDs {
+		if tc, ok := f.live[id]; ok {
+			tcs = append(tcs, tc)
+		}
+	}
+	return tcs, nil
+}
+
+func (f *fakeTicketClassService) delete(id int64) {
+	f.mu.Lock()
+	delete(f.live, id)
+	f.mu.Unlock()
+}
+
+// fakeWatchStream hands the updates sent by WatchAvailability to the test
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *invpb.AvailabilityUpdate
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(u *invpb.AvailabilityUpdate) error {
+	s.updates <- u
+	return nil
+}
+
+func TestWatchAvailabilitySendsDeletedUpdate(t *testing.T) {
+	tcSvc := &fakeTicketClassService{live: map[int64]models.TicketClass{
+		1: {ID: 1, EventID: "evt-1", Total: 10, Status: models.TicketClassStatusActive},
+		2: {ID: 2, EventID: "evt-1", Total: 20, Status: models.TicketClassStatusActive},
+	}}
+	bus := availability.NewLocalBus()
+	t.Cleanup(bus.Close)
+
+	s := &grpcService{tcSvc: tcSvc, bus: bus, l: pkgLog.InitializeTestZapLogger()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeWatchStream{ctx: ctx, updates: make(chan *invpb.AvailabilityUpdate, 8)}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.WatchAvailability(&invpb.WatchAvailabilityRequest{TicketClassIds: []string{"1", "2"}}, stream)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	next := func() *invpb.AvailabilityUpdate {
+		t.Helper()
+		select {
+		case u := <-stream.updates:
+			return u
+		case <-time.After(5 * time.Second):
+			t.Fatal("no update received")
+			return nil
+		}
+	}
+
+	var snapshot []string
+	for range 2 {
+		snapshot = append(snapshot, next().GetTicketClassId())
+	}
+	slices.Sort(snapshot)
+	if !slices.Equal(snapshot, []string{"1", "2"}) {
+		t.Fatalf("snapshot = %v, want classes 1 and 2", snapshot)
+	}
+
+	tcSvc.delete(2)
+	bus.Publish(ctx, availability.Change{TicketClassID: 2})
+
+	u := next()
+	if u.GetTicketClassId() != "2" || u.GetStatus() != availabilityStatusDeleted {
+		t.Errorf("update = %v, want a %s update for class 2", u, availabilityStatusDeleted)
+	}
+}
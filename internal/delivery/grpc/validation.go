@@ -1,153 +1,245 @@
 package grpc
 
 import (
+	"time"
+
 	invpb "github.com/vogiaan/ticketbottle-inventory/pkg/grpc/inventory"
-	"github.com/vogiaan/ticketbottle-inventory/pkg/util"
+)
+
+const (
+	// maxBatchCreateTicketClasses bounds a single BatchCreateTicketClasses call
+	maxBatchCreateTicketClasses = 200
+	// maxCloneTimeShiftSeconds bounds the sale window shift of a clone to 10 years either way,
+	// it also keeps the shift within the range of time.Duration
+	maxCloneTimeShiftSeconds = 10 * 365 * 24 * 60 * 60
 )
 
 func (s *grpcService) validateCreateTicketClassRequest(req *invpb.CreateTicketClassRequest) error {
-	if req.GetEventId() == "" {
-		return ErrValidationFailed
+	v := &validator{}
+	s.validateTicketClassFields(v, "", req)
+	return v.err()
+}
+
+// validateTicketClassFields checks a ticket class definition, prefix is prepended to every field path
+func (s *grpcService) validateTicketClassFields(v *validator, prefix string, req *invpb.CreateTicketClassRequest) {
+	v.required(prefix+"event_id", req.GetEventId())
+	v.required(prefix+"name", req.GetName())
+	v.required(prefix+"currency", req.GetCurrency())
+
+	if req.GetPriceCents() < 0 {
+		v.add(prefix+"price_cents", "must not be negative")
 	}
-	if req.GetName() == "" {
-		return ErrValidationFailed
+	if req.GetTotal() <= 0 {
+		v.add(prefix+"total", "must be greater than 0")
 	}
-	if req.GetPriceCents() < 0 {
-		return ErrValidationFailed
+	if req.GetMaxPerOrder() < 0 {
+		v.add(prefix+"max_per_order", "must not be negative")
+	} else if req.GetTotal() > 0 && req.GetMaxPerOrder() > req.GetTotal() {
+		v.add(prefix+"max_per_order", "must not exceed total")
 	}
-	if req.GetCurrency() == "" {
-		return ErrValidationFailed
+
+	v.saleWindow(prefix, req.GetStartSaleAt(), req.GetEndSaleAt())
+}
+
+func (s *grpcService) validateBatchCreateTicketClassesRequest(req *invpb.BatchCreateTicketClassesRequest) error {
+	v := &validator{}
+
+	if len(req.GetTicketClasses()) == 0 {
+		v.add("ticket_classes", "must not be empty")
 	}
-	if req.GetTotal() <= 0 {
-		return ErrValidationFailed
+	if len(req.GetTicketClasses()) > maxBatchCreateTicketClasses {
+		v.add("ticket_classes", "must not contain more than %d items", maxBatchCreateTicketClasses)
 	}
 
-	if req.GetStartSaleAt() != "" {
-		_, err := util.ParseISO8601(req.GetStartSaleAt())
-		if err != nil {
-			return ErrValidationFailed
+	// Names must be unique per event inside the batch as well
+	seen := make(map[string]bool, len(req.GetTicketClasses()))
+	for i, item := range req.GetTicketClasses() {
+		prefix := indexed("ticket_classes", i) + "."
+		s.validateTicketClassFields(v, prefix, item)
+
+		key := item.GetEventId() + "/" + item.GetName()
+		if seen[key] {
+			v.add(prefix+"name", "is used more than once for event %s in this batch", item.GetEventId())
 		}
+		seen[key] = true
 	}
 
-	if req.GetEndSaleAt() != "" {
-		_, err := util.ParseISO8601(req.GetEndSaleAt())
-		if err != nil {
-			return ErrValidationFailed
-		}
+	return v.err()
+}
+
+func (s *grpcService) validateCloneEventTicketClassesRequest(req *invpb.CloneEventTicketClassesRequest) error {
+	v := &validator{}
+
+	v.required("source_event_id", req.GetSourceEventId())
+	if v.required("target_event_id", req.GetTargetEventId()) && req.GetSourceEventId() == req.GetTargetEventId() {
+		v.add("target_event_id", "must differ from source_event_id")
+	}
+	if shift := req.GetTimeShiftSeconds(); shift > maxCloneTimeShiftSeconds || shift < -maxCloneTimeShiftSeconds {
+		v.add("time_shift_seconds", "must be within 10 years")
 	}
 
-	return nil
+	return v.err()
 }
 
 func (s *grpcService) validateUpdateTicketClassRequest(req *invpb.UpdateTicketClassRequest) error {
-	if req.GetId() == "" {
-		return ErrValidationFailed
+	v := &validator{}
+
+	// Update replaces currency, total and the sale window, so they are validated like on create
+	v.id("id", req.GetId())
+	v.required("currency", req.GetCurrency())
+
+	if req.GetPriceCents() < 0 {
+		v.add("price_cents", "must not be negative")
+	}
+	if req.GetTotal() <= 0 {
+		v.add("total", "must be greater than 0")
+	}
+	if req.GetMaxPerOrder() < 0 {
+		v.add("max_per_order", "must not be negative")
+	} else if req.GetTotal() > 0 && req.GetMaxPerOrder() > req.GetTotal() {
+		v.add("max_per_order", "must not exceed total")
 	}
+	if req.GetExpectedVersion() < 0 {
+		v.add("expected_version", "must not be negative")
+	}
+
+	v.saleWindow("", req.GetStartSaleAt(), req.GetEndSaleAt())
 
-	return nil
+	return v.err()
 }
 
 func (s *grpcService) validateFindOneTicketClassRequest(req *invpb.FindOneTicketClassRequest) error {
-	if req.GetId() == "" {
-		return ErrValidationFailed
-	}
-
-	return nil
+	v := &validator{}
+	v.id("id", req.GetId())
+	return v.err()
 }
 
 func (s *grpcService) validateFindManyTicketClassRequest(req *invpb.FindManyTicketClassRequest) error {
+	v := &validator{}
+
 	// Either event_id or ids must be provided
 	if req.GetEventId() == "" && len(req.GetIds()) == 0 {
-		return ErrValidationFailed
+		v.add("event_id", "event_id or ids is required")
+	}
+	for i, id := range req.GetIds() {
+		v.id(indexed("ids", i), id)
 	}
 
-	return nil
+	return v.err()
 }
 
 func (s *grpcService) validateDeleteTicketClassRequest(req *invpb.DeleteTicketClassRequest) error {
-	if req.GetId() == "" {
-		return ErrValidationFailed
-	}
+	v := &validator{}
+	v.id("id", req.GetId())
+	return v.err()
+}
 
-	return nil
+func (s *grpcService) validateRestoreTicketClassRequest(req *invpb.RestoreTicketClassRequest) error {
+	v := &validator{}
+	v.id("id", req.GetId())
+	return v.err()
 }
 
 func (s *grpcService) validateReserveRequest(req *invpb.ReserveRequest) error {
-	if req.GetOrderCode() == "" {
-		return ErrValidationFailed
-	}
+	v := &validator{}
+
+	v.required("order_code", req.GetOrderCode())
+
 	if len(req.GetItems()) == 0 {
-		return ErrValidationFailed
+		v.add("items", "must not be empty")
 	}
-	if req.GetExpiresAt() == "" {
-		return ErrValidationFailed
+	for i, item := range req.GetItems() {
+		s.validateReserveItem(v, indexed("items", i)+".", item)
 	}
 
-	for _, item := range req.GetItems() {
-		if err := s.validateReserveItem(item); err != nil {
-			return err
+	if v.required("expires_at", req.GetExpiresAt()) {
+		if expAt := v.timestamp("expires_at", req.GetExpiresAt()); expAt != nil && !expAt.After(time.Now()) {
+			v.add("expires_at", "must be in the future")
 		}
 	}
 
-	return nil
+	return v.err()
 }
 
-func (s *grpcService) validateReserveItem(item *invpb.ReserveItem) error {
-	if item.GetTicketClassId() == "" {
-		return ErrValidationFailed
-	}
+func (s *grpcService) validateReserveItem(v *validator, prefix string, item *invpb.ReserveItem) {
+	v.id(prefix+"ticket_class_id", item.GetTicketClassId())
 	if item.GetQuantity() <= 0 {
-		return ErrValidationFailed
+		v.add(prefix+"quantity", "must be greater than 0")
 	}
-
-	return nil
 }
 
 func (s *grpcService) validateConfirmRequest(req *invpb.ConfirmRequest) error {
-	if req.GetOrderCode() == "" {
-		return ErrValidationFailed
-	}
-
-	return nil
+	v := &validator{}
+	v.required("order_code", req.GetOrderCode())
+	return v.err()
 }
 
 func (s *grpcService) validateReleaseRequest(req *invpb.ReleaseRequest) error {
-	if req.GetOrderCode() == "" {
-		return ErrValidationFailed
-	}
-
-	return nil
+	v := &validator{}
+	v.required("order_code", req.GetOrderCode())
+	return v.err()
 }
 
 func (s *grpcService) validateGetAvailabilityRequest(req *invpb.GetAvailabilityRequest) error {
-	if req.GetTicketClassId() == "" {
-		return ErrValidationFailed
+	v := &validator{}
+	v.id("ticket_class_id", req.GetTicketClassId())
+	return v.err()
+}
+
+func (s *grpcService) validateGetEventAvailabilityRequest(req *invpb.GetEventAvailabilityRequest) error {
+	v := &validator{}
+	v.required("event_id", req.GetEventId())
+	return v.err()
+}
+
+func (s *grpcService) validateWatchAvailabilityRequest(req *invpb.WatchAvailabilityRequest) error {
+	v := &validator{}
+
+	// Either event_id or ticket_class_ids must be provided
+	if req.GetEventId() == "" && len(req.GetTicketClassIds()) == 0 {
+		v.add("event_id", "event_id or ticket_class_ids is required")
+	}
+	for i, id := range req.GetTicketClassIds() {
+		v.id(indexed("ticket_class_ids", i), id)
 	}
 
-	return nil
+	return v.err()
 }
 
 func (s *grpcService) validateCheckAvailabilityRequest(req *invpb.CheckAvailabilityRequest) error {
+	v := &validator{}
+
 	if len(req.GetItems()) == 0 {
-		return ErrValidationFailed
+		v.add("items", "must not be empty")
 	}
-
-	for _, item := range req.GetItems() {
-		if err := s.validateCheckAvailabilityItem(item); err != nil {
-			return err
-		}
+	for i, item := range req.GetItems() {
+		s.validateCheckAvailabilityItem(v, indexed("items", i)+".", item)
 	}
 
-	return nil
+	return v.err()
 }
 
-func (s *grpcService) validateCheckAvailabilityItem(item *invpb.CheckAvailabilityItem) error {
-	if item.GetTicketClassId() == "" {
-		return ErrValidationFailed
-	}
+func (s *grpcService) validateCheckAvailabilityItem(v *validator, prefix string, item *invpb.CheckAvailabilityItem) {
+	v.id(prefix+"ticket_class_id", item.GetTicketClassId())
 	if item.GetQuantity() <= 0 {
-		return ErrValidationFailed
+		v.add(prefix+"quantity", "must be greater than 0")
 	}
+}
+
+func (s *grpcService) validateCancelEventRequest(req *invpb.CancelEventRequest) error {
+	v := &validator{}
+	v.required("event_id", req.GetEventId())
+	return v.err()
+}
+
+func (s *grpcService) validatePauseSalesRequest(req *invpb.PauseSalesRequest) error {
+	v := &validator{}
+	v.required("event_id", req.GetEventId())
+	return v.err()
+}
 
-	return nil
+func (s *grpcService) validateResumeSalesRequest(req *invpb.ResumeSalesRequest) error {
+	v := &validator{}
+	v.required("event_id", req.GetEventId())
+	return v.err()
 }
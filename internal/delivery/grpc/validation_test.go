@@ -0,0 +1,338 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pkgErrors "github.com/vogiaan/ticketbottle-inventory/pkg/errors"
+	invpb "github.com/vogiaan/ticketbottle-inventory/pkg/grpc/inventory"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/util"
+	"google.golang.org/grpc/codes"
+)
+
+func TestValidateCreateTicketClassRequest(t *testing.T) {
+	valid := func() *invpb.CreateTicketClassRequest {
+		return &invpb.CreateTicketClassRequest{
+			EventId:     "evt-1",
+			Name:        "GA",
+			PriceCents:  1000,
+			Currency:    "USD",
+			Total:       100,
+			MaxPerOrder: 4,
+			StartSaleAt: "2026-05-01T10:00:00Z",
+			EndSaleAt:   "2026-05-02T10:00:00Z",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *invpb.CreateTicketClassRequest)
+		want   []string
+	}{
+		{name: "valid", modify: func(req *invpb.CreateTicketClassRequest) {}},
+		{name: "no sale window", modify: func(req *invpb.CreateTicketClassRequest) { req.StartSaleAt, req.EndSaleAt = "", "" }},
+		{name: "free", modify: func(req *invpb.CreateTicketClassRequest) { req.PriceCents = 0 }},
+		{name: "empty", modify: func(req *invpb.CreateTicketClassRequest) { *req = invpb.CreateTicketClassRequest{} },
+			want: []string{"currency", "event_id", "name", "total"}},
+		{name: "negative price", modify: func(req *invpb.CreateTicketClassRequest) { req.PriceCents = -1 }, want: []string{"price_cents"}},
+		{name: "zero total", modify: func(req *invpb.CreateTicketClassRequest) { req.Total = 0 }, want: []string{"total"}},
+		{name: "negative max per order", modify: func(req *invpb.CreateTicketClassRequest) { req.MaxPerOrder = -1 }, want: []string{"max_per_order"}},
+		{name: "max per order above total", modify: func(req *invpb.CreateTicketClassRequest) { req.MaxPerOrder = 101 }, want: []string{"max_per_order"}},
+		{name: "max per order equal to total", modify: func(req *invpb.CreateTicketClassRequest) { req.MaxPerOrder = 100 }},
+		{name: "invalid start", modify: func(req *invpb.CreateTicketClassRequest) { req.StartSaleAt = "2026-05-01" }, want: []string{"start_sale_at"}},
+		{name: "end before start", modify: func(req *invpb.CreateTicketClassRequest) { req.EndSaleAt = "2026-04-30T10:00:00Z" }, want: []string{"end_sale_at"}},
+		{name: "end equal to start", modify: func(req *invpb.CreateTicketClassRequest) { req.EndSaleAt = req.StartSaleAt }, want: []string{"end_sale_at"}},
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(req)
+			assertViolations(t, s.validateCreateTicketClassRequest(req), tt.want)
+		})
+	}
+}
+
+func TestValidateBatchCreateTicketClassesRequest(t *testing.T) {
+	item := func(eventID, name string) *invpb.CreateTicketClassRequest {
+		return &invpb.CreateTicketClassRequest{EventId: eventID, Name: name, Currency: "USD", Total: 10}
+	}
+
+	tooMany := make([]*invpb.CreateTicketClassRequest, maxBatchCreateTicketClasses+1)
+	for i := range tooMany {
+		tooMany[i] = item("evt-1", fmt.Sprintf("class-%d", i))
+	}
+
+	tests := []struct {
+		name  string
+		items []*invpb.CreateTicketClassRequest
+		want  []string
+	}{
+		{name: "valid", items: []*invpb.CreateTicketClassRequest{item("evt-1", "GA"), item("evt-1", "VIP")}},
+		{name: "same name in other events", items: []*invpb.CreateTicketClassRequest{item("evt-1", "GA"), item("evt-2", "GA")}},
+		{name: "empty", items: nil, want: []string{"ticket_classes"}},
+		{name: "duplicate name", items: []*invpb.CreateTicketClassRequest{item("evt-1", "GA"), item("evt-1", "VIP"), item("evt-1", "GA")},
+			want: []string{"ticket_classes[2].name"}},
+		{name: "invalid item", items: []*invpb.CreateTicketClassRequest{item("evt-1", "GA"), {EventId: "evt-1", Name: "VIP", Total: 10}},
+			want: []string{"ticket_classes[1].currency"}},
+		{name: "at the limit", items: tooMany[1:]},
+		{name: "too many", items: tooMany, want: []string{"ticket_classes"}},
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &invpb.BatchCreateTicketClassesRequest{TicketClasses: tt.items}
+			assertViolations(t, s.validateBatchCreateTicketClassesRequest(req), tt.want)
+		})
+	}
+}
+
+func TestValidateCloneEventTicketClassesRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		target string
+		shift  int64
+		want   []string
+	}{
+		{name: "valid", source: "evt-1", target: "evt-2"},
+		{name: "missing source", target: "evt-2", want: []string{"source_event_id"}},
+		{name: "missing target", source: "evt-1", want: []string{"target_event_id"}},
+		{name: "same event", source: "evt-1", target: "evt-1", want: []string{"target_event_id"}},
+		{name: "shift at the limit", source: "evt-1", target: "evt-2", shift: maxCloneTimeShiftSeconds},
+		{name: "negative shift at the limit", source: "evt-1", target: "evt-2", shift: -maxCloneTimeShiftSeconds},
+		{name: "shift too large", source: "evt-1", target: "evt-2", shift: maxCloneTimeShiftSeconds + 1, want: []string{"time_shift_seconds"}},
+		{name: "shift too small", source: "evt-1", target: "evt-2", shift: -maxCloneTimeShiftSeconds - 1, want: []string{"time_shift_seconds"}},
+		// time.Duration(x) * time.Second overflows past about 292 years
+		{name: "overflowing shift", source: "evt-1", target: "evt-2", shift: math.MaxInt64, want: []string{"time_shift_seconds"}},
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &invpb.CloneEventTicketClassesRequest{SourceEventId: tt.source, TargetEventId: tt.target, TimeShiftSeconds: tt.shift}
+			assertViolations(t, s.validateCloneEventTicketClassesRequest(req), tt.want)
+		})
+	}
+}
+
+func TestValidateUpdateTicketClassRequest(t *testing.T) {
+	valid := func() *invpb.UpdateTicketClassRequest {
+		return &invpb.UpdateTicketClassRequest{Id: "1", Currency: "USD", Total: 10, PriceCents: 500}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *invpb.UpdateTicketClassRequest)
+		want   []string
+	}{
+		{name: "valid", modify: func(req *invpb.UpdateTicketClassRequest) {}},
+		{name: "expected version", modify: func(req *invpb.UpdateTicketClassRequest) { req.ExpectedVersion = 3 }},
+		{name: "missing id", modify: func(req *invpb.UpdateTicketClassRequest) { req.Id = "" }, want: []string{"id"}},
+		{name: "non numeric id", modify: func(req *invpb.UpdateTicketClassRequest) { req.Id = "abc" }, want: []string{"id"}},
+		{name: "zero id", modify: func(req *invpb.UpdateTicketClassRequest) { req.Id = "0" }, want: []string{"id"}},
+		{name: "missing currency", modify: func(req *invpb.UpdateTicketClassRequest) { req.Currency = "" }, want: []string{"currency"}},
+		{name: "negative price", modify: func(req *invpb.UpdateTicketClassRequest) { req.PriceCents = -1 }, want: []string{"price_cents"}},
+		{name: "zero total", modify: func(req *invpb.UpdateTicketClassRequest) { req.Total = 0 }, want: []string{"total"}},
+		{name: "max per order above total", modify: func(req *invpb.UpdateTicketClassRequest) { req.MaxPerOrder = 11 }, want: []string{"max_per_order"}},
+		{name: "negative expected version", modify: func(req *invpb.UpdateTicketClassRequest) { req.ExpectedVersion = -1 }, want: []string{"expected_version"}},
+		{name: "end before start", modify: func(req *invpb.UpdateTicketClassRequest) {
+			req.StartSaleAt, req.EndSaleAt = "2026-05-02T10:00:00Z", "2026-05-01T10:00:00Z"
+		}, want: []string{"end_sale_at"}},
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(req)
+			assertViolations(t, s.validateUpdateTicketClassRequest(req), tt.want)
+		})
+	}
+}
+
+func TestValidateFindManyTicketClassRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		eventID string
+		ids     []string
+		want    []string
+	}{
+		{name: "by event", eventID: "evt-1"},
+		{name: "by ids", ids: []string{"1", "2"}},
+		{name: "neither", want: []string{"event_id"}},
+		{name: "invalid ids", ids: []string{"1", "x", "-2"}, want: []string{"ids[1]", "ids[2]"}},
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &invpb.FindManyTicketClassRequest{EventId: tt.eventID, Ids: tt.ids}
+			assertViolations(t, s.validateFindManyTicketClassRequest(req), tt.want)
+		})
+	}
+}
+
+func TestValidateReserveRequest(t *testing.T) {
+	future := util.TimeToISO8601Str(time.Now().Add(time.Hour).UTC())
+	past := util.TimeToISO8601Str(time.Now().Add(-time.Hour).UTC())
+
+	items := []*invpb.ReserveItem{{TicketClassId: "1", Quantity: 2}}
+
+	tests := []struct {
+		name string
+		req  *invpb.ReserveRequest
+		want []string
+	}{
+		{name: "valid", req: &invpb.ReserveRequest{OrderCode: "order-1", Items: items, ExpiresAt: future}},
+		{name: "empty", req: &invpb.ReserveRequest{}, want: []string{"expires_at", "items", "order_code"}},
+		{name: "expired", req: &invpb.ReserveRequest{OrderCode: "order-1", Items: items, ExpiresAt: past}, want: []string{"expires_at"}},
+		{name: "invalid expiry", req: &invpb.ReserveRequest{OrderCode: "order-1", Items: items, ExpiresAt: "tomorrow"}, want: []string{"expires_at"}},
+		{name: "invalid items", req: &invpb.ReserveRequest{
+			OrderCode: "order-1",
+			Items:     []*invpb.ReserveItem{{TicketClassId: "1", Quantity: 1}, {TicketClassId: "", Quantity: 0}, {TicketClassId: "x", Quantity: 1}},
+			ExpiresAt: future,
+		}, want: []string{"items[1].quantity", "items[1].ticket_class_id", "items[2].ticket_class_id"}},
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertViolations(t, s.validateReserveRequest(tt.req), tt.want)
+		})
+	}
+}
+
+func TestValidateCheckAvailabilityRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []*invpb.CheckAvailabilityItem
+		want  []string
+	}{
+		{name: "valid", items: []*invpb.CheckAvailabilityItem{{TicketClassId: "1", Quantity: 1}}},
+		{name: "empty", items: nil, want: []string{"items"}},
+		{name: "invalid item", items: []*invpb.CheckAvailabilityItem{{TicketClassId: "0", Quantity: -1}},
+			want: []string{"items[0].quantity", "items[0].ticket_class_id"}},
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &invpb.CheckAvailabilityRequest{Items: tt.items}
+			assertViolations(t, s.validateCheckAvailabilityRequest(req), tt.want)
+		})
+	}
+}
+
+func TestValidateWatchAvailabilityRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		eventID string
+		tcIDs   []string
+		want    []string
+	}{
+		{name: "by event", eventID: "evt-1"},
+		{name: "by ticket classes", tcIDs: []string{"1"}},
+		{name: "neither", want: []string{"event_id"}},
+		{name: "invalid ticket class", eventID: "evt-1", tcIDs: []string{"1", ""}, want: []string{"ticket_class_ids[1]"}},
+	}
+
+	s := &grpcService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &invpb.WatchAvailabilityRequest{EventId: tt.eventID, TicketClassIds: tt.tcIDs}
+			assertViolations(t, s.validateWatchAvailabilityRequest(req), tt.want)
+		})
+	}
+}
+
+// TestValidateSingleField covers the requests that only check one id or one required string
+func TestValidateSingleField(t *testing.T) {
+	s := &grpcService{}
+
+	tests := []struct {
+		name     string
+		validate func(value string) error
+		field    string
+		valid    string
+		invalid  []string
+	}{
+		{name: "FindOneTicketClass", validate: func(v string) error {
+			return s.validateFindOneTicketClassRequest(&invpb.FindOneTicketClassRequest{Id: v})
+		}, field: "id", valid: "1", invalid: []string{"", "0", "-1", "abc"}},
+		{name: "DeleteTicketClass", validate: func(v string) error {
+			return s.validateDeleteTicketClassRequest(&invpb.DeleteTicketClassRequest{Id: v})
+		}, field: "id", valid: "1", invalid: []string{"", "0", "abc"}},
+		{name: "RestoreTicketClass", validate: func(v string) error {
+			return s.validateRestoreTicketClassRequest(&invpb.RestoreTicketClassRequest{Id: v})
+		}, field: "id", valid: "1", invalid: []string{"", "0", "abc"}},
+		{name: "GetAvailability", validate: func(v string) error {
+			return s.validateGetAvailabilityRequest(&invpb.GetAvailabilityRequest{TicketClassId: v})
+		}, field: "ticket_class_id", valid: "7", invalid: []string{"", "0", "1.5"}},
+		{name: "Confirm", validate: func(v string) error {
+			return s.validateConfirmRequest(&invpb.ConfirmRequest{OrderCode: v})
+		}, field: "order_code", valid: "order-1", invalid: []string{""}},
+		{name: "Release", validate: func(v string) error {
+			return s.validateReleaseRequest(&invpb.ReleaseRequest{OrderCode: v})
+		}, field: "order_code", valid: "order-1", invalid: []string{""}},
+		{name: "GetEventAvailability", validate: func(v string) error {
+			return s.validateGetEventAvailabilityRequest(&invpb.GetEventAvailabilityRequest{EventId: v})
+		}, field: "event_id", valid: "evt-1", invalid: []string{""}},
+		{name: "CancelEvent", validate: func(v string) error {
+			return s.validateCancelEventRequest(&invpb.CancelEventRequest{EventId: v})
+		}, field: "event_id", valid: "evt-1", invalid: []string{""}},
+		{name: "PauseSales", validate: func(v string) error {
+			return s.validatePauseSalesRequest(&invpb.PauseSalesRequest{EventId: v})
+		}, field: "event_id", valid: "evt-1", invalid: []string{""}},
+		{name: "ResumeSales", validate: func(v string) error {
+			return s.validateResumeSalesRequest(&invpb.ResumeSalesRequest{EventId: v})
+		}, field: "event_id", valid: "evt-1", invalid: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertViolations(t, tt.validate(tt.valid), nil)
+			for _, v := range tt.invalid {
+				assertViolations(t, tt.validate(v), []string{tt.field})
+			}
+		})
+	}
+}
+
+// assertViolations checks that err reports exactly the fields in want, nil want means the request is valid
+func assertViolations(t *testing.T, err error, want []string) {
+	t.Helper()
+
+	if len(want) == 0 {
+		if err != nil {
+			t.Errorf("validation error = %v, want nil", err)
+		}
+		return
+	}
+
+	var gErr *pkgErrors.GRPCError
+	if !errors.As(err, &gErr) {
+		t.Fatalf("validation error = %v, want a *GRPCError", err)
+	}
+	if gErr.GrpcCode != codes.InvalidArgument {
+		t.Errorf("code = %v, want %v", gErr.GrpcCode, codes.InvalidArgument)
+	}
+
+	got := make([]string, 0, len(gErr.FieldViolations))
+	for _, fv := range gErr.FieldViolations {
+		got = append(got, fv.Field)
+	}
+	slices.Sort(got)
+	want = slices.Sorted(slices.Values(want))
+
+	if !slices.Equal(got, want) {
+		t.Errorf("violations = [%s], want [%s]", strings.Join(got, ", "), strings.Join(want, ", "))
+	}
+}
@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	pkgErrors "github.com/vogiaan/ticketbottle-inventory/pkg/errors"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/util"
+)
+
+// validator collects every field violation of a request instead of stopping at the first one
+type validator struct {
+	violations []pkgErrors.FieldViolation
+}
+
+func (v *validator) add(field, format string, args ...any) {
+	v.violations = append(v.violations, pkgErrors.FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) required(field, value string) bool {
+	if value == "" {
+		v.add(field, "is required")
+		return false
+	}
+	return true
+}
+
+// id checks that value is a positive numeric ticket class id
+func (v *validator) id(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	if id, err := strconv.ParseInt(value, 10, 64); err != nil || id <= 0 {
+		v.add(field, "must be a positive numeric id")
+	}
+}
+
+// timestamp parses an optional ISO8601 value, nil is returned when it is empty or invalid
+func (v *validator) timestamp(field, value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	t, err := util.ParseISO8601(value)
+	if err != nil {
+		v.add(field, "must be an ISO8601 timestamp like %s", util.ISO8601Format)
+		return nil
+	}
+	return &t
+}
+
+// saleWindow checks the sale window bounds and that the end comes after the start
+func (v *validator) saleWindow(prefix, start, end string) {
+	startAt := v.timestamp(prefix+"start_sale_at", start)
+	endAt := v.timestamp(prefix+"end_sale_at", end)
+
+	if startAt != nil && endAt != nil && !endAt.After(*startAt) {
+		v.add(prefix+"end_sale_at", "must be after start_sale_at")
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return ErrValidationFailed.WithFieldViolations(v.violations)
+}
+
+// indexed returns the path of a repeated field element, such as items[0]
+func indexed(field string, i int) string {
+	return fmt.Sprintf("%s[%d]", field, i)
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"maps"
+	"strconv"
+)
+
+// Kind is the machine readable reason of a domain error, clients branch on it
+type Kind string
+
+const (
+	KindTicketClassNotFound    Kind = "TICKET_CLASS_NOT_FOUND"
+	KindTicketClassNotSellable Kind = "TICKET_CLASS_NOT_SELLABLE"
+	KindTicketClassInUse       Kind = "TICKET_CLASS_IN_USE"
+	KindTicketClassNameTaken   Kind = "TICKET_CLASS_NAME_TAKEN"
+	KindTicketClassCancelled   Kind = "TICKET_CLASS_CANCELLED"
+	KindCapacityBelowCommitted Kind = "CAPACITY_BELOW_COMMITTED"
+	KindVersionMismatch        Kind = "VERSION_MISMATCH"
+	KindInsufficientStock      Kind = "INSUFFICIENT_STOCK"
+	KindOutsideSaleWindow      Kind = "OUTSIDE_SALE_WINDOW"
+	KindOverPerOrderLimit      Kind = "OVER_PER_ORDER_LIMIT"
+	KindSalesPaused            Kind = "SALES_PAUSED"
+	KindReservationNotFound    Kind = "RESERVATION_NOT_FOUND"
+	KindReservationExists      Kind = "RESERVATION_EXISTS"
+	KindReservationNotActive   Kind = "RESERVATION_NOT_ACTIVE"
+	KindReservationExpired     Kind = "RESERVATION_EXPIRED"
+	KindCounterMismatch        Kind = "COUNTER_MISMATCH"
+	KindInvariantViolated      Kind = "INVARIANT_VIOLATED"
+)
+
+// Metadata keys attached to domain errors
+const (
+	MetaTicketClassID     = "ticket_class_id"
+	MetaEventID           = "event_id"
+	MetaOrderCode         = "order_code"
+	MetaReservationID     = "reservation_id"
+	MetaAvailableQuantity = "available_quantity"
+	MetaRequestedQuantity = "requested_quantity"
+	MetaMaxPerOrder       = "max_per_order"
+	MetaCurrentVersion    = "current_version"
+	MetaStatus            = "status"
+	MetaConstraint        = "constraint"
+)
+
+// Error is a business rule violation. Errors of the same Kind match with errors.Is,
+// so the package level sentinels keep working after metadata is added.
+type Error struct {
+	Kind     Kind
+	Message  string
+	Metadata map[string]string
+}
+
+func New(kind Kind, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind
+}
+
+// With returns a copy of the error carrying an extra metadata entry
+func (e *Error) With(key, value string) *Error {
+	md := make(map[string]string, len(e.Metadata)+1)
+	maps.Copy(md, e.Metadata)
+	md[key] = value
+
+	return &Error{
+		Kind:     e.Kind,
+		Message:  e.Message,
+		Metadata: md,
+	}
+}
+
+// WithInt is With for integer values such as ids and quantities
+func (e *Error) WithInt(key string, value int64) *Error {
+	return e.With(key, strconv.FormatInt(value, 10))
+}
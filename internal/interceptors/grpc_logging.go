@@ -2,10 +2,12 @@ package interceptors
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/vogiaan/ticketbottle-inventory/pkg/logger"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
@@ -32,8 +34,49 @@ func GrpcLoggingInterceptor(l logger.Logger) grpc.UnaryServerInterceptor {
 			ip = p.Addr.String()
 		}
 
+		// Probes call the health service every few seconds
+		if isHealthCheck(info.FullMethod) {
+			l.Debugf(ctx, "%s %s - %dms - %s", info.FullMethod, errCode, duration.Milliseconds(), ip)
+			return resp, err
+		}
+
 		l.Infof(ctx, "%s %s - %dms - %s", info.FullMethod, errCode, duration.Milliseconds(), ip)
 
 		return resp, err
 	}
 }
+
+func GrpcStreamLoggingInterceptor(l logger.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := ss.Context()
+
+		startTime := time.Now()
+		err := handler(srv, ss)
+		duration := time.Since(startTime)
+
+		errCode := "OK"
+		if err != nil {
+			st, _ := status.FromError(err)
+			errCode = st.Code().String()
+		}
+
+		ip := "unknown"
+		if p, ok := peer.FromContext(ctx); ok {
+			ip = p.Addr.String()
+		}
+
+		l.Infof(ctx, "%s %s - stream %dms - %s", info.FullMethod, errCode, duration.Milliseconds(), ip)
+
+		return err
+	}
+}
+
+// isHealthCheck reports whether the method belongs to the grpc.health.v1 service
+func isHealthCheck(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
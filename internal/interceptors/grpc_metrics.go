@@ -0,0 +1,49 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func GrpcMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		startTime := time.Now()
+		resp, err := handler(ctx, req)
+
+		observeGrpc(info.FullMethod, err, time.Since(startTime))
+
+		return resp, err
+	}
+}
+
+func GrpcStreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		startTime := time.Now()
+		err := handler(srv, ss)
+
+		observeGrpc(info.FullMethod, err, time.Since(startTime))
+
+		return err
+	}
+}
+
+func observeGrpc(method string, err error, duration time.Duration) {
+	code := status.Code(err).String()
+
+	metrics.GrpcRequests.WithLabelValues(method, code).Inc()
+	metrics.GrpcDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+}
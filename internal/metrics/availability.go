@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const availabilityQueryTimeout = 5 * time.Second
+
+// availabilityCollector sums the counters of the active ticket classes per event on every scrape.
+// Every replica reports the same values, aggregate them with max rather than sum.
+type availabilityCollector struct {
+	l         pkgLog.Logger
+	db        *gorm.DB
+	capacity  *prometheus.Desc
+	reserved  *prometheus.Desc
+	sold      *prometheus.Desc
+	available *prometheus.Desc
+}
+
+type eventAvailabilityRow struct {
+	EventID   string
+	Capacity  int64
+	Reserved  int64
+	Sold      int64
+	Available int64
+}
+
+// RegisterAvailability exposes the per-event inventory_event_* gauges
+func RegisterAvailability(l pkgLog.Logger, db *gorm.DB) {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "event", name), help, []string{"event_id"}, nil)
+	}
+
+	Registry.MustRegister(&availabilityCollector{
+		l:         l,
+		db:        db,
+		capacity:  desc("capacity_tickets", "Total tickets of the active ticket classes of the event."),
+		reserved:  desc("reserved_tickets", "Tickets held by active reservations."),
+		sold:      desc("sold_tickets", "Tickets sold."),
+		available: desc("available_tickets", "Tickets that can still be reserved."),
+	})
+}
+
+func (c *availabilityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.capacity
+	ch <- c.reserved
+	ch <- c.sold
+	ch <- c.available
+}
+
+func (c *availabilityCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), availabilityQueryTimeout)
+	defer cancel()
+
+	var rows []eventAvailabilityRow
+	if err := c.db.WithContext(ctx).
+		Model(&models.TicketClass{}).
+		Select(`event_id,
+			SUM(total) AS capacity,
+			SUM(reserved) AS reserved,
+			SUM(sold) AS sold,
+			SUM(GREATEST(total - reserved - sold, 0)) AS available`).
+		Where("status = ?", models.TicketClassStatusActive).
+		Group("event_id").
+		Scan(&rows).Error; err != nil {
+		// Skip the gauges for this scrape, the rest of the metrics are still served
+		c.l.Warnf(ctx, "metrics.availabilityCollector.Collect: %v", err)
+		return
+	}
+
+	for _, r := range rows {
+		ch <- prometheus.MustNewConstMetric(c.capacity, prometheus.GaugeValue, float64(r.Capacity), r.EventID)
+		ch <- prometheus.MustNewConstMetric(c.reserved, prometheus.GaugeValue, float64(r.Reserved), r.EventID)
+		ch <- prometheus.MustNewConstMetric(c.sold, prometheus.GaugeValue, float64(r.Sold), r.EventID)
+		ch <- prometheus.MustNewConstMetric(c.available, prometheus.GaugeValue, float64(r.Available), r.EventID)
+	}
+}
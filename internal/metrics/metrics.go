@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "inventory"
+
+// Reservation outcomes, used as the outcome label of the reservation counters
+const (
+	ReservationCreated   = "created"
+	ReservationConfirmed = "confirmed"
+	ReservationCancelled = "cancelled"
+	ReservationExpired   = "expired"
+)
+
+// Registry holds every metric of the service, it is served by Server
+var Registry = prometheus.NewRegistry()
+
+var (
+	GrpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "gRPC requests handled, by full method and status code.",
+	}, []string{"method", "code"})
+
+	GrpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC request latency, by full method and status code. Streams are measured until they end.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"method", "code"})
+
+	Reservations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_total",
+		Help:      "Reservations by ticket class and outcome (created, confirmed, cancelled, expired).",
+	}, []string{"ticket_class_id", "outcome"})
+
+	ReservationTickets = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservation_tickets_total",
+		Help:      "Tickets held by reservations, by ticket class and outcome.",
+	}, []string{"ticket_class_id", "outcome"})
+
+	ExpiryBatchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "expiry",
+		Name:      "batch_size",
+		Help:      "Reservations expired per batch by the expiry worker.",
+		Buckets:   []float64{0, 1, 5, 10, 25, 50, 100, 250, 500, 1000},
+	})
+
+	ExpiryLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "expiry",
+		Name:      "lag_seconds",
+		Help:      "Time between a hold's expires_at and the moment it was released.",
+		Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	})
+
+	OutboxDeadEvents = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "dead_events_total",
+		Help:      "Outbox events the relay gave up on after the maximum number of attempts.",
+	})
+
+	OrderEventsFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "order_events",
+		Name:      "failed_total",
+		Help:      "Order events the consumer gave up on after the maximum number of attempts.",
+	})
+
+	JobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "job",
+		Name:      "runs_total",
+		Help:      "Scheduled job runs, by job and result (ok, failed).",
+	}, []string{"job", "result"})
+
+	JobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "job",
+		Name:      "duration_seconds",
+		Help:      "Duration of scheduled job runs.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"job"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		GrpcRequests,
+		GrpcDuration,
+		Reservations,
+		ReservationTickets,
+		ExpiryBatchSize,
+		ExpiryLag,
+		OutboxDeadEvents,
+		OrderEventsFailed,
+		JobRuns,
+		JobDuration,
+	)
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+// Server exposes the Registry over HTTP. It implements the workers.Worker interface so it is
+// started and stopped with the other background components.
+type Server struct {
+	l   pkgLog.Logger
+	srv *http.Server
+
+	mu sync.Mutex
+	// err is set when the server stopped serving on its own
+	err error
+}
+
+func NewServer(l pkgLog.Logger, port int, path string) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(path, promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+
+	return &Server{
+		l: l,
+		srv: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+func (s *Server) Start(ctx context.Context) {
+	go func() {
+		s.l.Infof(ctx, "Metrics server is listening on %s", s.srv.Addr)
+		if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.l.Errorf(ctx, "metrics.Server.ListenAndServe: %v", err)
+
+			s.mu.Lock()
+			s.err = err
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// Healthy returns the error that stopped the server, it implements workers.Worker
+func (s *Server) Healthy() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return fmt.Errorf("metrics server stopped: %w", s.err)
+	}
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) {
+	// The root context is already cancelled at shutdown, in-flight scrapes still get to finish
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err := s.srv.Shutdown(ctx); err != nil {
+		s.l.Warnf(ctx, "metrics.Server.Shutdown: %v", err)
+	}
+	s.l.Info(ctx, "Metrics server stopped")
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS processed_message;
+DROP TABLE IF EXISTS outbox_event;
+DROP TABLE IF EXISTS event_sales_pause;
+DROP TABLE IF EXISTS reservation;
+DROP TABLE IF EXISTS ticket_class;
@@ -0,0 +1,104 @@
+-- Baseline schema. Databases created by the old AutoMigrate startup already have some of these
+-- tables, from any earlier version of the models, so every statement is idempotent and the
+-- ALTERs below bring those tables up to the same shape as a fresh install.
+
+CREATE TABLE IF NOT EXISTS ticket_class (
+    id            bigserial PRIMARY KEY,
+    event_id      text NOT NULL,
+    name          text NOT NULL,
+    price_cents   bigint NOT NULL,
+    currency      text NOT NULL,
+    total         bigint NOT NULL,
+    reserved      bigint NOT NULL DEFAULT 0,
+    sold          bigint NOT NULL DEFAULT 0,
+    oversold      bigint NOT NULL DEFAULT 0,
+    max_per_order bigint NOT NULL DEFAULT 0,
+    sale_start_at timestamptz,
+    sale_end_at   timestamptz,
+    status        text NOT NULL DEFAULT 'ACTIVE',
+    version       bigint NOT NULL DEFAULT 1,
+    created_at    timestamptz,
+    updated_at    timestamptz,
+    deleted_at    timestamptz
+);
+
+ALTER TABLE ticket_class
+    ADD COLUMN IF NOT EXISTS oversold      bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_per_order bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS version       bigint NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS deleted_at    timestamptz;
+
+-- AutoMigrate created idx_event_name over every row, soft deleted names must be reusable
+DROP INDEX IF EXISTS idx_event_name;
+CREATE UNIQUE INDEX idx_event_name ON ticket_class (event_id, name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_event_id ON ticket_class (event_id);
+CREATE INDEX IF NOT EXISTS idx_ticket_class_deleted_at ON ticket_class (deleted_at);
+
+CREATE TABLE IF NOT EXISTS reservation (
+    id              bigserial PRIMARY KEY,
+    order_code      text NOT NULL,
+    ticket_class_id bigint NOT NULL,
+    qty             bigint NOT NULL,
+    expires_at      timestamptz NOT NULL,
+    status          text NOT NULL,
+    created_at      timestamptz,
+    updated_at      timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_ticket ON reservation (order_code, ticket_class_id);
+CREATE INDEX IF NOT EXISTS idx_ticket_status_expires ON reservation (ticket_class_id, expires_at, status);
+
+-- AutoMigrate created the foreign key with ON DELETE CASCADE, which would drop the holds of a
+-- deleted ticket class, so it is replaced whatever its name
+DO $$
+DECLARE
+    fk record;
+BEGIN
+    FOR fk IN
+        SELECT conname FROM pg_constraint
+        WHERE contype = 'f'
+          AND conrelid = 'reservation'::regclass
+          AND confrelid = 'ticket_class'::regclass
+    LOOP
+        EXECUTE format('ALTER TABLE reservation DROP CONSTRAINT %I', fk.conname);
+    END LOOP;
+END
+$$;
+
+ALTER TABLE reservation
+    ADD CONSTRAINT fk_ticket_class_reservations
+    FOREIGN KEY (ticket_class_id) REFERENCES ticket_class (id) ON DELETE RESTRICT;
+
+CREATE TABLE IF NOT EXISTS event_sales_pause (
+    event_id   text PRIMARY KEY,
+    reason     text NOT NULL DEFAULT '',
+    paused_at  timestamptz NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS outbox_event (
+    id           bigserial PRIMARY KEY,
+    topic        text NOT NULL,
+    event_type   text NOT NULL,
+    key          text NOT NULL,
+    payload      bytea NOT NULL,
+    attempts     bigint NOT NULL DEFAULT 0,
+    last_error   text,
+    published_at timestamptz,
+    created_at   timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox_event (id) WHERE published_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS processed_message (
+    message_id   text PRIMARY KEY,
+    topic        text NOT NULL,
+    event_type   text NOT NULL,
+    order_code   text NOT NULL,
+    outcome      text NOT NULL,
+    error        text,
+    processed_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_processed_message_order_code ON processed_message (order_code);
@@ -0,0 +1,10 @@
+ALTER TABLE reservation
+    DROP CONSTRAINT IF EXISTS chk_reservation_status,
+    DROP CONSTRAINT IF EXISTS chk_reservation_qty;
+
+ALTER TABLE ticket_class
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_status,
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_capacity,
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_oversold,
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_sold,
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_reserved;
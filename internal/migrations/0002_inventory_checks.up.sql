@@ -0,0 +1,43 @@
+-- Inventory invariants. Constraints are dropped first in case AutoMigrate already created them.
+-- They are added NOT VALID, so new writes are checked right away without scanning the existing rows,
+-- then validated. A table with drifted counters keeps the constraint unvalidated instead of failing the
+-- migration, the reconciliation job validates it once the counters are repaired.
+
+ALTER TABLE ticket_class
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_reserved,
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_sold,
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_oversold,
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_capacity,
+    DROP CONSTRAINT IF EXISTS chk_ticket_class_status,
+    ADD CONSTRAINT chk_ticket_class_reserved CHECK (reserved >= 0) NOT VALID,
+    ADD CONSTRAINT chk_ticket_class_sold CHECK (sold >= 0) NOT VALID,
+    ADD CONSTRAINT chk_ticket_class_oversold CHECK (oversold >= 0) NOT VALID,
+    ADD CONSTRAINT chk_ticket_class_capacity CHECK (reserved + sold <= total + oversold) NOT VALID,
+    ADD CONSTRAINT chk_ticket_class_status CHECK (status IN ('ACTIVE', 'INACTIVE', 'CANCELLED')) NOT VALID;
+
+ALTER TABLE reservation
+    DROP CONSTRAINT IF EXISTS chk_reservation_qty,
+    DROP CONSTRAINT IF EXISTS chk_reservation_status,
+    ADD CONSTRAINT chk_reservation_qty CHECK (qty > 0) NOT VALID,
+    ADD CONSTRAINT chk_reservation_status CHECK (status IN ('ACTIVE', 'CONFIRMED', 'EXPIRED', 'CANCELLED')) NOT VALID;
+
+DO $$
+DECLARE
+    c record;
+BEGIN
+    FOR c IN
+        SELECT conrelid::regclass AS tbl, conname
+        FROM pg_constraint
+        WHERE conrelid IN ('ticket_class'::regclass, 'reservation'::regclass)
+            AND contype = 'c'
+            AND NOT convalidated
+        ORDER BY conname
+    LOOP
+        BEGIN
+            EXECUTE format('ALTER TABLE %s VALIDATE CONSTRAINT %I', c.tbl, c.conname);
+        EXCEPTION WHEN check_violation THEN
+            RAISE WARNING 'constraint % left NOT VALID, existing rows violate it: %', c.conname, SQLERRM;
+        END;
+    END LOOP;
+END
+$$;
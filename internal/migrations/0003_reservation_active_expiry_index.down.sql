@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_reservation_active_expires;
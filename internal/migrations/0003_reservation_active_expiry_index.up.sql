@@ -0,0 +1,2 @@
+-- Serves the expiry worker: the earliest active hold and the batch of expired ones
+CREATE INDEX IF NOT EXISTS idx_reservation_active_expires ON reservation (expires_at) WHERE status = 'ACTIVE';
@@ -0,0 +1,9 @@
+-- Keep one row per message id so the old key can be restored
+DELETE FROM processed_message a
+USING processed_message b
+WHERE a.message_id = b.message_id
+  AND a.topic > b.topic;
+
+ALTER TABLE processed_message
+    DROP CONSTRAINT IF EXISTS processed_message_pkey,
+    ADD CONSTRAINT processed_message_pkey PRIMARY KEY (message_id);
@@ -0,0 +1,4 @@
+-- Producers only keep message ids unique within their topic
+ALTER TABLE processed_message
+    DROP CONSTRAINT IF EXISTS processed_message_pkey,
+    ADD CONSTRAINT processed_message_pkey PRIMARY KEY (topic, message_id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_outbox_published_at;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox_event (id) WHERE published_at IS NULL;
+
+ALTER TABLE outbox_event
+    DROP COLUMN IF EXISTS dead_at,
+    DROP COLUMN IF EXISTS claimed_until;
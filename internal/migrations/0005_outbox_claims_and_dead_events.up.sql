@@ -0,0 +1,11 @@
+-- The relay claims rows before publishing them outside of a transaction, and gives up on rows
+-- the broker keeps rejecting
+ALTER TABLE outbox_event
+    ADD COLUMN IF NOT EXISTS claimed_until timestamptz,
+    ADD COLUMN IF NOT EXISTS dead_at       timestamptz;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox_event (id) WHERE published_at IS NULL AND dead_at IS NULL;
+
+-- Serves the retention cleanup of published rows
+CREATE INDEX IF NOT EXISTS idx_outbox_published_at ON outbox_event (published_at) WHERE published_at IS NOT NULL;
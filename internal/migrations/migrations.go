@@ -0,0 +1,8 @@
+// Package migrations embeds the versioned SQL files of the inventory schema.
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql and applied with pkg/migrate.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,167 @@
+package migrations_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vogiaan/ticketbottle-inventory/internal/migrations"
+	"github.com/vogiaan/ticketbottle-inventory/internal/testdb"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/migrate"
+)
+
+// autoMigrateBaseline is the schema the AutoMigrate startup created before versioned migrations
+const autoMigrateBaseline = `
+CREATE TABLE ticket_class (
+	id bigserial, event_id text NOT NULL, name text NOT NULL, price_cents bigint NOT NULL,
+	currency text NOT NULL, total bigint NOT NULL, reserved bigint NOT NULL DEFAULT 0,
+	sold bigint NOT NULL DEFAULT 0, sale_start_at timestamptz, sale_end_at timestamptz,
+	status text NOT NULL DEFAULT 'ACTIVE', created_at timestamptz, updated_at timestamptz,
+	PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX idx_event_name ON ticket_class (event_id, name);
+CREATE INDEX idx_event_id ON ticket_class (event_id);
+
+CREATE TABLE reservation (
+	id bigserial, order_code text NOT NULL, ticket_class_id bigint NOT NULL, qty bigint NOT NULL,
+	expires_at timestamptz NOT NULL, status text NOT NULL, created_at timestamptz, updated_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_ticket_class_reservations FOREIGN KEY (ticket_class_id)
+		REFERENCES ticket_class (id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX idx_order_ticket ON reservation (order_code, ticket_class_id);
+CREATE INDEX idx_ticket_status_expires ON reservation (ticket_class_id, expires_at, status);
+
+INSERT INTO ticket_class (event_id, name, price_cents, currency, total, reserved)
+VALUES ('evt-1', 'GA', 1000, 'USD', 10, 2);
+INSERT INTO reservation (order_code, ticket_class_id, qty, expires_at, status)
+VALUES ('order-1', 1, 2, now() + interval '10 minutes', 'ACTIVE');
+`
+
+func TestFilesLoad(t *testing.T) {
+	mgr, err := migrate.New(nil, migrations.FS)
+	if err != nil {
+		t.Fatalf("migrate.New(): %v", err)
+	}
+	if mgr.Latest() == 0 {
+		t.Error("no migrations embedded")
+	}
+}
+
+func TestUpgradeAutoMigrateBaseline(t *testing.T) {
+	db := testdb.Open(t)
+	ctx := context.Background()
+
+	if _, err := db.ExecContext(ctx, autoMigrateBaseline); err != nil {
+		t.Fatalf("failed to create baseline schema: %v", err)
+	}
+
+	mgr := newMigrator(t, db)
+	if _, err := mgr.Up(ctx); err != nil {
+		t.Fatalf("Up() on the baseline schema: %v", err)
+	}
+
+	assertSchema(t, db)
+
+	var oversold, maxPerOrder, version int
+	if err := db.QueryRowContext(ctx,
+		"SELECT oversold, max_per_order, version FROM ticket_class WHERE id = 1",
+	).Scan(&oversold, &maxPerOrder, &version); err != nil {
+		t.Fatalf("failed to read upgraded ticket class: %v", err)
+	}
+	if oversold != 0 || maxPerOrder != 0 || version != 1 {
+		t.Errorf("upgraded ticket class = (oversold %d, max_per_order %d, version %d), want (0, 0, 1)",
+			oversold, maxPerOrder, version)
+	}
+}
+
+func TestUpFromEmptyAndDownToZero(t *testing.T) {
+	db := testdb.Open(t)
+	ctx := context.Background()
+
+	mgr := newMigrator(t, db)
+	if _, err := mgr.Up(ctx); err != nil {
+		t.Fatalf("Up(): %v", err)
+	}
+	if _, err := mgr.To(ctx, 0); err != nil {
+		t.Fatalf("To(0): %v", err)
+	}
+	if _, err := mgr.Up(ctx); err != nil {
+		t.Fatalf("Up() after To(0): %v", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO ticket_class (event_id, name, price_cents, currency, total, reserved)
+		VALUES ('evt-1', 'GA', 1000, 'USD', 10, 2);
+		INSERT INTO reservation (order_code, ticket_class_id, qty, expires_at, status)
+		VALUES ('order-1', 1, 2, now() + interval '10 minutes', 'ACTIVE');
+	`); err != nil {
+		t.Fatalf("failed to seed: %v", err)
+	}
+
+	assertSchema(t, db)
+}
+
+func TestProcessedMessageKeyedByTopic(t *testing.T) {
+	db := testdb.Open(t)
+	ctx := context.Background()
+
+	if _, err := newMigrator(t, db).Up(ctx); err != nil {
+		t.Fatalf("Up(): %v", err)
+	}
+
+	insert := `INSERT INTO processed_message (topic, message_id, event_type, order_code, outcome, processed_at)
+		VALUES ($1, 'evt-1', 'order.paid', 'order-1', 'APPLIED', now())`
+	if _, err := db.ExecContext(ctx, insert, "orders"); err != nil {
+		t.Fatalf("first insert: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, insert, "orders.v2"); err != nil {
+		t.Errorf("same message id on another topic: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, insert, "orders"); err == nil {
+		t.Error("same message id on the same topic was accepted")
+	}
+}
+
+func newMigrator(t *testing.T, db *sql.DB) *migrate.Migrator {
+	t.Helper()
+
+	mgr, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		t.Fatalf("migrate.New(): %v", err)
+	}
+	return mgr
+}
+
+// assertSchema checks the parts of the schema AutoMigrate got wrong, ticket class 1 must have an active hold
+func assertSchema(t *testing.T, db *sql.DB) {
+	t.Helper()
+	ctx := context.Background()
+
+	var delType string
+	if err := db.QueryRowContext(ctx, `
+		SELECT confdeltype::text FROM pg_constraint
+		WHERE contype = 'f' AND conrelid = 'reservation'::regclass AND confrelid = 'ticket_class'::regclass
+	`).Scan(&delType); err != nil {
+		t.Fatalf("failed to read reservation foreign key: %v", err)
+	}
+	if delType != "r" {
+		t.Errorf("reservation foreign key on delete = %q, want RESTRICT (r)", delType)
+	}
+
+	_, err := db.ExecContext(ctx, "DELETE FROM ticket_class WHERE id = 1")
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23503" {
+		t.Errorf("deleting a ticket class with holds: err = %v, want foreign key violation", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `
+		UPDATE ticket_class SET deleted_at = now() WHERE id = 1;
+		INSERT INTO ticket_class (event_id, name, price_cents, currency, total)
+		VALUES ('evt-1', 'GA', 1000, 'USD', 10);
+	`); err != nil {
+		t.Errorf("reusing the name of a soft deleted ticket class: %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// EventSalesPause stops new holds for every ticket class of an event while the row exists
+type EventSalesPause struct {
+	EventID   string    `gorm:"primarykey"`
+	Reason    string    `gorm:"not null;default:''"`
+	PausedAt  time.Time `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName specifies the table name for EventSalesPause
+func (EventSalesPause) TableName() string {
+	return "event_sales_pause"
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the state change it describes.
+// The relay worker publishes pending rows in ID order and marks them as published. ClaimedUntil is set
+// while a relay publishes the row, DeadAt once the relay gave up on it.
+type OutboxEvent struct {
+	ID           int64  `gorm:"primarykey;autoIncrement;index:idx_outbox_pending,where:published_at IS NULL AND dead_at IS NULL"`
+	Topic        string `gorm:"not null"`
+	EventType    string `gorm:"not null"`
+	Key          string `gorm:"not null"`
+	Payload      []byte `gorm:"not null"`
+	Attempts     int    `gorm:"not null;default:0"`
+	LastError    string
+	PublishedAt  *time.Time `gorm:"index:idx_outbox_published_at,where:published_at IS NOT NULL"`
+	ClaimedUntil *time.Time
+	DeadAt       *time.Time
+	CreatedAt    time.Time
+}
+
+// TableName specifies the table name for OutboxEvent
+func (OutboxEvent) TableName() string {
+	return "outbox_event"
+}
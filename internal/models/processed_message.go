@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// ProcessedMessage records inbound messages that were already handled, so redeliveries are ignored.
+// Message IDs come from the producers, they are only unique within a topic.
+type ProcessedMessage struct {
+	Topic       string                  `gorm:"primarykey"`
+	MessageID   string                  `gorm:"primarykey"`
+	EventType   string                  `gorm:"not null"`
+	OrderCode   string                  `gorm:"not null;index"`
+	Outcome     ProcessedMessageOutcome `gorm:"not null"`
+	Error       string
+	ProcessedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for ProcessedMessage
+func (ProcessedMessage) TableName() string {
+	return "processed_message"
+}
+
+type ProcessedMessageOutcome string
+
+const (
+	// ProcessedMessageOutcomeApplied means the message changed reservation state
+	ProcessedMessageOutcomeApplied ProcessedMessageOutcome = "APPLIED"
+	// ProcessedMessageOutcomeSkipped means the reservations were not in a state the message applies to,
+	// e.g. they were already confirmed or released
+	ProcessedMessageOutcomeSkipped ProcessedMessageOutcome = "SKIPPED"
+	// ProcessedMessageOutcomeFailed means the message kept failing and was given up on, the order needs
+	// manual repair. Deleting the row lets a replayed message be processed again.
+	ProcessedMessageOutcomeFailed ProcessedMessageOutcome = "FAILED"
+)
@@ -31,6 +31,12 @@ func (r *Reservation) IsExpired() bool {
 	return time.Now().UTC().After(r.ExpiresAt) && r.Status == ReservationStatusActive
 }
 
+// CHECK constraints on reservation, created by migration 0002_inventory_checks
+const (
+	CheckReservationQty    = "chk_reservation_qty"
+	CheckReservationStatus = "chk_reservation_status"
+)
+
 type ReservationStatus string
 
 const (
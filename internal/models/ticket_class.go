@@ -2,26 +2,35 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // TicketClass represents ticket types for an event (GA, VIP, Seat Zone A, etc.)
 type TicketClass struct {
 	ID          int64  `gorm:"primarykey;autoIncrement"`
-	EventID     string `gorm:"not null;uniqueIndex:idx_event_name;index:idx_event_id"`
-	Name        string `gorm:"not null;uniqueIndex:idx_event_name"`
+	EventID     string `gorm:"not null;uniqueIndex:idx_event_name,where:deleted_at IS NULL;index:idx_event_id"`
+	Name        string `gorm:"not null;uniqueIndex:idx_event_name,where:deleted_at IS NULL"`
 	PriceCents  int64  `gorm:"not null"`
 	Currency    string `gorm:"not null"`
 	Total       int    `gorm:"not null"`
 	Reserved    int    `gorm:"not null;default:0"`
 	Sold        int    `gorm:"not null;default:0"`
+	Oversold    int    `gorm:"not null;default:0"`
+	MaxPerOrder int    `gorm:"not null;default:0"` // 0 means no limit
 	SaleStartAt *time.Time
 	SaleEndAt   *time.Time
 	Status      TicketClassStatus `gorm:"not null;default:'ACTIVE'"`
+	Version     int64             `gorm:"not null;default:1"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 
 	// Relations
-	Reservations []Reservation `gorm:"constraint:OnDelete:CASCADE"`
+	Reservations []Reservation `gorm:"constraint:OnDelete:RESTRICT"`
+
+	// SalesPause is loaded by the service layer, nil when sales of the event are not paused
+	SalesPause *EventSalesPause `gorm:"-"`
 }
 
 // TableName specifies the table name for TicketClass
@@ -29,9 +38,53 @@ func (TicketClass) TableName() string {
 	return "ticket_class"
 }
 
+// IsSellable reports whether new holds may be taken on the ticket class
+func (tc *TicketClass) IsSellable() bool {
+	return tc.Status == TicketClassStatusActive
+}
+
+// AvailableQty returns how many tickets can still be reserved.
+// It never goes below zero, even when a forced capacity change left the class oversold.
+func (tc *TicketClass) AvailableQty() int {
+	available := tc.Total - tc.Reserved - tc.Sold
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
+// SaleWindow reports where now falls relative to the sale window, a missing bound leaves that side open
+func (tc *TicketClass) SaleWindow(now time.Time) SaleWindowState {
+	if tc.SaleStartAt != nil && now.Before(*tc.SaleStartAt) {
+		return SaleWindowUpcoming
+	}
+	if tc.SaleEndAt != nil && !now.Before(*tc.SaleEndAt) {
+		return SaleWindowEnded
+	}
+	return SaleWindowOpen
+}
+
+// CHECK constraints on ticket_class, created by migration 0002_inventory_checks
+const (
+	CheckTicketClassCapacity = "chk_ticket_class_capacity"
+	CheckTicketClassReserved = "chk_ticket_class_reserved"
+	CheckTicketClassSold     = "chk_ticket_class_sold"
+	CheckTicketClassOversold = "chk_ticket_class_oversold"
+	CheckTicketClassStatus   = "chk_ticket_class_status"
+)
+
 type TicketClassStatus string
 
 const (
-	TicketClassStatusActive   TicketClassStatus = "ACTIVE"
-	TicketClassStatusInactive TicketClassStatus = "INACTIVE"
+	TicketClassStatusActive    TicketClassStatus = "ACTIVE"
+	TicketClassStatusInactive  TicketClassStatus = "INACTIVE"
+	TicketClassStatusCancelled TicketClassStatus = "CANCELLED"
+)
+
+type SaleWindowState string
+
+const (
+	SaleWindowUpcoming SaleWindowState = "UPCOMING"
+	SaleWindowOpen     SaleWindowState = "OPEN"
+	SaleWindowEnded    SaleWindowState = "ENDED"
 )
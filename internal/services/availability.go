@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	"github.com/vogiaan/ticketbottle-inventory/internal/domain"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+)
+
+// AvailabilityReason explains why a quantity of a ticket class can or cannot be held
+type AvailabilityReason string
+
+const (
+	AvailabilityReasonOK                AvailabilityReason = "OK"
+	AvailabilityReasonNotFound          AvailabilityReason = "NOT_FOUND"
+	AvailabilityReasonInactive          AvailabilityReason = "INACTIVE"
+	AvailabilityReasonOutsideSaleWindow AvailabilityReason = "OUTSIDE_SALE_WINDOW"
+	AvailabilityReasonSalesPaused       AvailabilityReason = "SALES_PAUSED"
+	AvailabilityReasonOverPerOrderLimit AvailabilityReason = "OVER_PER_ORDER_LIMIT"
+	AvailabilityReasonInsufficient      AvailabilityReason = "INSUFFICIENT"
+)
+
+// checkSellable decides whether qty tickets of tc can be held by one order.
+// Reserve and CheckAvailability both go through it so they never disagree.
+func checkSellable(tc models.TicketClass, paused bool, qty int, now time.Time) AvailabilityReason {
+	if !tc.IsSellable() {
+		return AvailabilityReasonInactive
+	}
+	if tc.SaleWindow(now) != models.SaleWindowOpen {
+		return AvailabilityReasonOutsideSaleWindow
+	}
+	if paused {
+		return AvailabilityReasonSalesPaused
+	}
+	if tc.MaxPerOrder > 0 && qty > tc.MaxPerOrder {
+		return AvailabilityReasonOverPerOrderLimit
+	}
+	if tc.AvailableQty() < qty {
+		return AvailabilityReasonInsufficient
+	}
+	return AvailabilityReasonOK
+}
+
+// err converts a rejection reason to the error Reserve returns for it
+func (r AvailabilityReason) err(tc models.TicketClass, qty int) error {
+	var e *domain.Error
+	switch r {
+	case AvailabilityReasonOK:
+		return nil
+	case AvailabilityReasonNotFound:
+		e = ErrTicketClassNotFound
+	case AvailabilityReasonInactive:
+		e = ErrTicketClassNotSellable.With(domain.MetaStatus, string(tc.Status))
+	case AvailabilityReasonOutsideSaleWindow:
+		e = ErrOutsideSaleWindow
+	case AvailabilityReasonSalesPaused:
+		e = ErrSalesPaused.With(domain.MetaEventID, tc.EventID)
+	case AvailabilityReasonOverPerOrderLimit:
+		e = ErrOverPerOrderLimit.WithInt(domain.MetaMaxPerOrder, int64(tc.MaxPerOrder))
+	default:
+		e = ErrInsufficientStock
+	}
+
+	return e.WithInt(domain.MetaTicketClassID, tc.ID).
+		WithInt(domain.MetaAvailableQuantity, int64(tc.AvailableQty())).
+		WithInt(domain.MetaRequestedQuantity, int64(qty))
+}
+
+// publishAvailability tells watchers that the counters of these ticket classes changed.
+// It must be called after the transaction commits, otherwise watchers may read stale rows.
+func publishAvailability(ctx context.Context, bus availability.Publisher, tcIDs ...int64) {
+	if bus == nil || len(tcIDs) == 0 {
+		return
+	}
+
+	changes := make([]availability.Change, len(tcIDs))
+	for i, tcID := range tcIDs {
+		changes[i] = availability.Change{TicketClassID: tcID}
+	}
+
+	bus.Publish(ctx, changes...)
+}
+
+// sortedTicketClassIDs returns the keys of a ticket_class_id -> qty map in ascending order
+func sortedTicketClassIDs(m map[int64]int) []int64 {
+	tcIDs := make([]int64, 0, len(m))
+	for tcID := range m {
+		tcIDs = append(tcIDs, tcID)
+	}
+	slices.Sort(tcIDs)
+
+	return tcIDs
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+)
+
+func TestCheckSellable(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	active := models.TicketClass{Status: models.TicketClassStatusActive, Total: 10, Reserved: 3, Sold: 2}
+
+	tests := []struct {
+		name   string
+		tc     func(tc *models.TicketClass)
+		paused bool
+		qty    int
+		want   AvailabilityReason
+	}{
+		{name: "available", qty: 5, want: AvailabilityReasonOK},
+		{name: "inactive", tc: func(tc *models.TicketClass) { tc.Status = models.TicketClassStatusInactive }, qty: 1, want: AvailabilityReasonInactive},
+		{name: "cancelled", tc: func(tc *models.TicketClass) { tc.Status = models.TicketClassStatusCancelled }, qty: 1, want: AvailabilityReasonInactive},
+		{name: "sale not started", tc: func(tc *models.TicketClass) { tc.SaleStartAt = &after }, qty: 1, want: AvailabilityReasonOutsideSaleWindow},
+		{name: "sale ended", tc: func(tc *models.TicketClass) { tc.SaleEndAt = &before }, qty: 1, want: AvailabilityReasonOutsideSaleWindow},
+		{name: "sale ends now", tc: func(tc *models.TicketClass) { tc.SaleEndAt = &now }, qty: 1, want: AvailabilityReasonOutsideSaleWindow},
+		{name: "inside sale window", tc: func(tc *models.TicketClass) { tc.SaleStartAt, tc.SaleEndAt = &before, &after }, qty: 1, want: AvailabilityReasonOK},
+		{name: "paused", paused: true, qty: 1, want: AvailabilityReasonSalesPaused},
+		{name: "over per-order limit", tc: func(tc *models.TicketClass) { tc.MaxPerOrder = 2 }, qty: 3, want: AvailabilityReasonOverPerOrderLimit},
+		{name: "at per-order limit", tc: func(tc *models.TicketClass) { tc.MaxPerOrder = 2 }, qty: 2, want: AvailabilityReasonOK},
+		{name: "insufficient", qty: 6, want: AvailabilityReasonInsufficient},
+		{name: "oversold", tc: func(tc *models.TicketClass) { tc.Total = 4 }, qty: 1, want: AvailabilityReasonInsufficient},
+		// Reasons are checked in this order, the first one that applies wins
+		{name: "inactive before paused", tc: func(tc *models.TicketClass) { tc.Status = models.TicketClassStatusInactive }, paused: true, qty: 1, want: AvailabilityReasonInactive},
+		{name: "window before paused", tc: func(tc *models.TicketClass) { tc.SaleEndAt = &before }, paused: true, qty: 1, want: AvailabilityReasonOutsideSaleWindow},
+		{name: "paused before limit", tc: func(tc *models.TicketClass) { tc.MaxPerOrder = 1 }, paused: true, qty: 2, want: AvailabilityReasonSalesPaused},
+		{name: "limit before stock", tc: func(tc *models.TicketClass) { tc.MaxPerOrder = 1 }, qty: 20, want: AvailabilityReasonOverPerOrderLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := active
+			if tt.tc != nil {
+				tt.tc(&tc)
+			}
+
+			if got := checkSellable(tc, tt.paused, tt.qty, now); got != tt.want {
+				t.Errorf("checkSellable() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortedTicketClassIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   map[int64]int
+		want []int64
+	}{
+		{name: "empty", in: map[int64]int{}, want: []int64{}},
+		{name: "single", in: map[int64]int{7: 1}, want: []int64{7}},
+		{name: "unordered", in: map[int64]int{30: 1, 2: 5, 11: 2, 1: 1}, want: []int64{1, 2, 11, 30}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sortedTicketClassIDs(tt.in); !slices.Equal(got, tt.want) {
+				t.Errorf("sortedTicketClassIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/domain"
+)
+
+var (
+	ErrTicketClassNotFound    = domain.New(domain.KindTicketClassNotFound, "ticket class not found")
+	ErrCapacityBelowCommitted = domain.New(domain.KindCapacityBelowCommitted, "total capacity is below reserved and sold tickets")
+	ErrVersionMismatch        = domain.New(domain.KindVersionMismatch, "ticket class version does not match")
+	ErrTicketClassInUse       = domain.New(domain.KindTicketClassInUse, "ticket class has active holds or sales")
+	ErrTicketClassNameTaken   = domain.New(domain.KindTicketClassNameTaken, "ticket class name is already used in this event")
+	ErrTicketClassCancelled   = domain.New(domain.KindTicketClassCancelled, "ticket class was cancelled with its event")
+	ErrTicketClassNotSellable = domain.New(domain.KindTicketClassNotSellable, "ticket class is not on sale")
+	ErrSalesPaused            = domain.New(domain.KindSalesPaused, "sales are paused for this event")
+	ErrOutsideSaleWindow      = domain.New(domain.KindOutsideSaleWindow, "ticket class is outside its sale window")
+	ErrOverPerOrderLimit      = domain.New(domain.KindOverPerOrderLimit, "quantity exceeds the per-order limit")
+	ErrInsufficientStock      = domain.New(domain.KindInsufficientStock, "insufficient stock")
+	ErrReservationNotFound    = domain.New(domain.KindReservationNotFound, "no reservations found for order")
+	ErrReservationExists      = domain.New(domain.KindReservationExists, "order already holds this ticket class")
+	ErrReservationNotActive   = domain.New(domain.KindReservationNotActive, "reservation is not active")
+	ErrReservationExpired     = domain.New(domain.KindReservationExpired, "reservation has expired")
+	ErrCounterMismatch        = domain.New(domain.KindCounterMismatch, "ticket class counters do not match its reservations")
+	ErrUnknownOrderEvent      = errors.New("unknown order event type")
+)
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	"github.com/vogiaan/ticketbottle-inventory/internal/domain"
+	"github.com/vogiaan/ticketbottle-inventory/internal/metrics"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// cancelEventBatchSize bounds how many reservations are cancelled per transaction
+const cancelEventBatchSize = 500
+
+type EventService interface {
+	Cancel(ctx context.Context, eventID string) (CancelEventOutput, error)
+	PauseSales(ctx context.Context, in PauseSalesInput) error
+	ResumeSales(ctx context.Context, eventID string) error
+	GetAvailability(ctx context.Context, eventID string) (EventAvailabilityOutput, error)
+}
+
+type implEventService struct {
+	l    pkgLog.Logger
+	repo *pkgGorm.Repository
+	bus  availability.Publisher
+}
+
+func NewEventService(l pkgLog.Logger, repo *pkgGorm.Repository, bus availability.Publisher) EventService {
+	return &implEventService{
+		l:    l,
+		repo: repo,
+		bus:  bus,
+	}
+}
+
+// Cancel freezes every ticket class of the event and releases its active holds in batches.
+// Each step commits on its own and is idempotent, so an interrupted run is resumed by calling Cancel again.
+func (s implEventService) Cancel(ctx context.Context, eventID string) (_ CancelEventOutput, err error) {
+	ctx, span := startSpan(ctx, "service.event.Cancel", attrEventID.String(eventID))
+	defer func() { endSpan(span, err) }()
+
+	// Step 1: Mark all ticket classes as cancelled so no new holds can be taken
+	tcIDs, err := s.freezeTicketClasses(ctx, eventID)
+	if err != nil {
+		s.l.Errorf(ctx, "service.event.Cancel.freezeTicketClasses: %v", err)
+		return CancelEventOutput{}, err
+	}
+
+	publishAvailability(ctx, s.bus, tcIDs...)
+
+	out := CancelEventOutput{
+		FrozenTicketClasses: len(tcIDs),
+	}
+
+	// Step 2: Cancel active holds batch by batch, restoring the reserved counters
+	for {
+		cnt, qty, err := s.cancelReservationBatch(ctx, tcIDs, cancelEventBatchSize)
+		if err != nil {
+			s.l.Errorf(ctx, "service.event.Cancel.cancelReservationBatch: %v", err)
+			return CancelEventOutput{}, err
+		}
+
+		out.CancelledReservations += cnt
+		out.ReleasedQty += qty
+
+		if cnt < cancelEventBatchSize {
+			break
+		}
+	}
+
+	// Step 3: Collect orders that already paid, they have to be refunded by the order service
+	if err := s.repo.WithContext(ctx).
+		Model(&models.Reservation{}).
+		Distinct("order_code").
+		Where("ticket_class_id IN ? AND status = ?", tcIDs, models.ReservationStatusConfirmed).
+		Order("order_code").
+		Pluck("order_code", &out.RefundOrderCodes).Error; err != nil {
+		s.l.Errorf(ctx, "service.event.Cancel.FindRefundOrders: %v", err)
+		return CancelEventOutput{}, err
+	}
+
+	s.l.Infof(ctx, "service.event.Cancel: event_id=%s frozen %d ticket classes, cancelled %d reservations (qty=%d), %d orders need refunds",
+		eventID, out.FrozenTicketClasses, out.CancelledReservations, out.ReleasedQty, len(out.RefundOrderCodes))
+
+	return out, nil
+}
+
+// PauseSales stops new holds for the event. Confirm and Release keep working.
+func (s implEventService) PauseSales(ctx context.Context, in PauseSalesInput) (err error) {
+	ctx, span := startSpan(ctx, "service.event.PauseSales", attrEventID.String(in.EventID))
+	defer func() { endSpan(span, err) }()
+
+	var tcIDs []int64
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		tcIDs = nil
+
+		// Step 1: Lock the ticket classes so holds that are in flight finish before the pause is visible
+		var tcs []models.TicketClass
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("event_id = ?", in.EventID).
+			Order("id").
+			Find(&tcs).Error; err != nil {
+			s.l.Errorf(ctx, "service.event.PauseSales.LockTicketClasses: %v", err)
+			return err
+		}
+
+		if len(tcs) == 0 {
+			s.l.Warnf(ctx, "service.event.PauseSales: no ticket classes found for event_id=%s", in.EventID)
+			return gorm.ErrRecordNotFound
+		}
+
+		tcIDs = make([]int64, len(tcs))
+		for i, tc := range tcs {
+			tcIDs[i] = tc.ID
+		}
+
+		// Step 2: Record the pause, pausing again only updates the reason
+		p := models.EventSalesPause{
+			EventID:  in.EventID,
+			Reason:   in.Reason,
+			PausedAt: time.Now().UTC(),
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"reason", "updated_at"}),
+		}).Create(&p).Error; err != nil {
+			s.l.Errorf(ctx, "service.event.PauseSales.Upsert: %v", err)
+			return err
+		}
+
+		s.l.Warnf(ctx, "service.event.PauseSales: sales paused for event_id=%s (reason=%q)", in.EventID, in.Reason)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	publishAvailability(ctx, s.bus, tcIDs...)
+	return nil
+}
+
+func (s implEventService) ResumeSales(ctx context.Context, eventID string) (err error) {
+	ctx, span := startSpan(ctx, "service.event.ResumeSales", attrEventID.String(eventID))
+	defer func() { endSpan(span, err) }()
+
+	if err := s.repo.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Delete(&models.EventSalesPause{}).Error; err != nil {
+		s.l.Errorf(ctx, "service.event.ResumeSales: %v", err)
+		return err
+	}
+
+	s.l.Infof(ctx, "service.event.ResumeSales: sales resumed for event_id=%s", eventID)
+
+	var tcIDs []int64
+	if err := s.repo.WithContext(ctx).
+		Model(&models.TicketClass{}).
+		Where("event_id = ?", eventID).
+		Order("id").
+		Pluck("id", &tcIDs).Error; err != nil {
+		// Sales are resumed, watchers only see it on their next change
+		s.l.Warnf(ctx, "service.event.ResumeSales.FindTicketClasses: %v", err)
+		return nil
+	}
+
+	publishAvailability(ctx, s.bus, tcIDs...)
+	return nil
+}
+
+// GetAvailability reads every ticket class of the event together with its sales pause in one query
+func (s implEventService) GetAvailability(ctx context.Context, eventID string) (_ EventAvailabilityOutput, err error) {
+	ctx, span := startSpan(ctx, "service.event.GetAvailability", attrEventID.String(eventID))
+	defer func() { endSpan(span, err) }()
+
+	var rows []eventAvailabilityRow
+	if err := s.repo.WithContext(ctx).
+		Model(&models.TicketClass{}).
+		Select("ticket_class.*, p.reason AS pause_reason, p.paused_at AS pause_paused_at").
+		Joins("LEFT JOIN event_sales_pause p ON p.event_id = ticket_class.event_id").
+		Where("ticket_class.event_id = ?", eventID).
+		Order("ticket_class.id").
+		Scan(&rows).Error; err != nil {
+		s.l.Errorf(ctx, "service.event.GetAvailability: %v", err)
+		return EventAvailabilityOutput{}, err
+	}
+
+	if len(rows) == 0 {
+		s.l.Warnf(ctx, "service.event.GetAvailability: no ticket classes found for event_id=%s", eventID)
+		return EventAvailabilityOutput{}, gorm.ErrRecordNotFound
+	}
+
+	return s.buildEventAvailability(eventID, rows, time.Now().UTC()), nil
+}
+
+func (s implEventService) freezeTicketClasses(ctx context.Context, eventID string) ([]int64, error) {
+	var tcIDs []int64
+
+	err := s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var tcs []models.TicketClass
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("event_id = ?", eventID).
+			Order("id").
+			Find(&tcs).Error; err != nil {
+			return err
+		}
+
+		if len(tcs) == 0 {
+			s.l.Warnf(ctx, "service.event.freezeTicketClasses: no ticket classes found for event_id=%s", eventID)
+			return gorm.ErrRecordNotFound
+		}
+
+		tcIDs = make([]int64, len(tcs))
+		for i, tc := range tcs {
+			tcIDs[i] = tc.ID
+		}
+
+		return tx.Model(&models.TicketClass{}).
+			Where("id IN ?", tcIDs).
+			Update("status", models.TicketClassStatusCancelled).Error
+	})
+
+	return tcIDs, err
+}
+
+// cancelReservationBatch cancels up to limit active reservations of the given ticket classes.
+// It returns how many reservations were cancelled and the total quantity released.
+func (s implEventService) cancelReservationBatch(ctx context.Context, tcIDs []int64, limit int) (int, int, error) {
+	cnt, released := 0, 0
+	var changed []int64
+	var cancelled []models.Reservation
+
+	err := s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		// Reset the results, the transaction may be retried
+		cnt, released, changed, cancelled = 0, 0, nil, nil
+
+		var rs []models.Reservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "order_code", "ticket_class_id", "qty").
+			Where("ticket_class_id IN ? AND status = ?", tcIDs, models.ReservationStatusActive).
+			Order("id").
+			Limit(limit).
+			Find(&rs).Error; err != nil {
+			return err
+		}
+
+		if len(rs) == 0 {
+			return nil
+		}
+
+		tcQtyMap := make(map[int64]int)
+		rIDs := make([]int64, 0, len(rs))
+		for _, r := range rs {
+			tcQtyMap[r.TicketClassID] += r.Qty
+			rIDs = append(rIDs, r.ID)
+		}
+
+		changed = sortedTicketClassIDs(tcQtyMap)
+
+		for _, tcID := range changed {
+			qty := tcQtyMap[tcID]
+			result := tx.Model(&models.TicketClass{}).
+				Where("id = ?", tcID).
+				Where("reserved >= ?", qty). // Safety check
+				Update("reserved", gorm.Expr("reserved - ?", qty))
+
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				s.l.Errorf(ctx, "service.event.cancelReservationBatch: insufficient reserved tickets for ticket_class_id=%d (needed=%d)", tcID, qty)
+				return ErrCounterMismatch.WithInt(domain.MetaTicketClassID, tcID)
+			}
+
+			released += qty
+		}
+
+		if err := tx.Model(&models.Reservation{}).
+			Where("id IN ?", rIDs).
+			Update("status", models.ReservationStatusCancelled).Error; err != nil {
+			return err
+		}
+
+		if err := enqueueReservationsCancelled(tx, rs, CancelReasonEventCancelled, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		cnt = len(rIDs)
+		cancelled = rs
+		return nil
+	})
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	publishAvailability(ctx, s.bus, changed...)
+	recordReservations(metrics.ReservationCancelled, cancelled...)
+
+	return cnt, released, nil
+}
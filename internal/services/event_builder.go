@@ -0,0 +1,55 @@
+package service
+
+import (
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+)
+
+// buildEventAvailability rolls the ticket classes of an event up to event level.
+// The event sale window is the most open window among its ACTIVE classes.
+func (s implEventService) buildEventAvailability(eventID string, rows []eventAvailabilityRow, now time.Time) EventAvailabilityOutput {
+	out := EventAvailabilityOutput{
+		EventID:       eventID,
+		TicketClasses: make([]models.TicketClass, len(rows)),
+		SaleWindow:    models.SaleWindowEnded,
+		At:            now,
+	}
+
+	if rows[0].PausePausedAt != nil {
+		out.SalesPause = &models.EventSalesPause{
+			EventID:  eventID,
+			Reason:   rows[0].PauseReason,
+			PausedAt: *rows[0].PausePausedAt,
+		}
+	}
+
+	for i, row := range rows {
+		tc := row.TicketClass
+		tc.SalesPause = out.SalesPause
+		out.TicketClasses[i] = tc
+
+		out.Total += tc.Total
+		out.Reserved += tc.Reserved
+		out.Sold += tc.Sold
+		out.Available += tc.AvailableQty()
+
+		if !tc.IsSellable() {
+			continue
+		}
+
+		switch tc.SaleWindow(now) {
+		case models.SaleWindowOpen:
+			out.SaleWindow = models.SaleWindowOpen
+			if out.SalesPause == nil && tc.AvailableQty() > 0 {
+				out.OnSale = true
+			}
+		case models.SaleWindowUpcoming:
+			if out.SaleWindow == models.SaleWindowEnded {
+				out.SaleWindow = models.SaleWindowUpcoming
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+// recordingPublisher keeps the ticket class ids of every published change
+type recordingPublisher struct {
+	tcIDs []int64
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, changes ...availability.Change) {
+	for _, c := range changes {
+		p.tcIDs = append(p.tcIDs, c.TicketClassID)
+	}
+}
+
+func TestResumeSalesPublishesAvailability(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	bus := &recordingPublisher{}
+	s := implEventService{l: pkgLog.InitializeTestZapLogger(), repo: repo, bus: bus}
+
+	mock.ExpectExec(`DELETE FROM "event_sales_pause" WHERE event_id = \$1`).
+		WithArgs("evt-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT "id" FROM "ticket_class" WHERE event_id = \$1 .*ORDER BY id`).
+		WithArgs("evt-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	if err := s.ResumeSales(context.Background(), "evt-1"); err != nil {
+		t.Fatalf("ResumeSales() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	if want := []int64{1, 2}; !slices.Equal(bus.tcIDs, want) {
+		t.Errorf("published %v, want %v", bus.tcIDs, want)
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+)
+
+type CancelEventOutput struct {
+	FrozenTicketClasses   int
+	CancelledReservations int
+	ReleasedQty           int
+	RefundOrderCodes      []string
+}
+
+type PauseSalesInput struct {
+	EventID string
+	Reason  string
+}
+
+// EventAvailabilityOutput is a snapshot of every ticket class of an event taken at At.
+// The rollup counters sum all classes, SaleWindow and OnSale only consider ACTIVE ones.
+type EventAvailabilityOutput struct {
+	EventID       string
+	TicketClasses []models.TicketClass
+	Total         int
+	Reserved      int
+	Sold          int
+	Available     int
+	SaleWindow    models.SaleWindowState
+	OnSale        bool
+	SalesPause    *models.EventSalesPause
+	At            time.Time
+}
+
+// eventAvailabilityRow is a ticket class joined with the sales pause of its event
+type eventAvailabilityRow struct {
+	models.TicketClass
+	PauseReason   string
+	PausePausedAt *time.Time
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/metrics"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+)
+
+// recordReservations counts reservations that reached an outcome. Like publishAvailability it is
+// called after the transaction commits, a retried transaction would count them twice otherwise.
+func recordReservations(outcome string, rs ...models.Reservation) {
+	for _, r := range rs {
+		tcID := strconv.FormatInt(r.TicketClassID, 10)
+		metrics.Reservations.WithLabelValues(tcID, outcome).Inc()
+		metrics.ReservationTickets.WithLabelValues(tcID, outcome).Add(float64(r.Qty))
+	}
+}
+
+// recordExpiryLag observes how long each hold stayed active past its expiry
+func recordExpiryLag(rs []models.Reservation, releasedAt time.Time) {
+	for _, r := range rs {
+		metrics.ExpiryLag.Observe(max(releasedAt.Sub(r.ExpiresAt), 0).Seconds())
+	}
+}
+
+// reservationTicketClassIDs returns the ticket classes of the reservations in ascending order
+func reservationTicketClassIDs(rs []models.Reservation) []int64 {
+	tcQty := make(map[int64]int, len(rs))
+	for _, r := range rs {
+		tcQty[r.TicketClassID] += r.Qty
+	}
+
+	return sortedTicketClassIDs(tcQty)
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/metrics"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/publisher"
+	"gorm.io/gorm"
+)
+
+// outboxRelayLockKey is the advisory lock taken while claiming, so two relays never claim at the same time
+const outboxRelayLockKey int64 = 0x696e765f6f7574 // "inv_out"
+
+type OutboxService interface {
+	PublishPending(ctx context.Context, batchSize int) (int, error)
+	DeletePublished(ctx context.Context, before time.Time, batchSize int) (int, error)
+}
+
+type implOutboxService struct {
+	l            pkgLog.Logger
+	repo         *pkgGorm.Repository
+	pub          publisher.Publisher
+	maxAttempts  int
+	claimTimeout time.Duration
+}
+
+func NewOutboxService(
+	l pkgLog.Logger,
+	repo *pkgGorm.Repository,
+	pub publisher.Publisher,
+	maxAttempts int,
+	claimTimeout time.Duration,
+) OutboxService {
+	return &implOutboxService{
+		l:            l,
+		repo:         repo,
+		pub:          pub,
+		maxAttempts:  maxAttempts,
+		claimTimeout: claimTimeout,
+	}
+}
+
+// outboxPublishResult tells which claimed events the broker accepted and which it rejected
+type outboxPublishResult struct {
+	published []int64
+	failed    map[int64]error
+}
+
+// PublishPending publishes the oldest pending events and marks them as published.
+// Rows are claimed in a short transaction and published after it committed, so a slow broker holds
+// no row lock or transaction. Rows are only marked after the publisher accepted them, so delivery
+// is at least once. An event the broker keeps rejecting is given up on after maxAttempts.
+func (s implOutboxService) PublishPending(ctx context.Context, batchSize int) (_ int, err error) {
+	ctx, span := startSpan(ctx, "service.outbox.PublishPending")
+	defer func() { endSpan(span, err) }()
+
+	if batchSize <= 0 || batchSize > 1000 {
+		batchSize = 100 // Default batch size
+	}
+
+	// Step 1: Claim the oldest pending events
+	evs, until, err := s.claimPending(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(evs) == 0 {
+		return 0, nil
+	}
+
+	// Step 2: Publish them, giving up when the claim runs out so no other relay publishes them meanwhile
+	pubCtx, cancel := context.WithDeadline(ctx, until)
+	res := s.publish(pubCtx, evs)
+	cancel()
+
+	// Step 3: Record the results and release the claim, also when we are shutting down
+	dead, err := s.settle(context.WithoutCancel(ctx), evs, res)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(res.failed) > 0 {
+		return len(res.published), fmt.Errorf("failed to publish %d outbox events (%d dead)", len(res.failed), dead)
+	}
+
+	return len(res.published), nil
+}
+
+// claimPending claims up to batchSize pending events in ID order until the returned time.
+// Nothing is claimed while another relay holds a live claim, which keeps the per-key order across
+// replicas, and the claim of a relay that crashed runs out after claimTimeout.
+func (s implOutboxService) claimPending(ctx context.Context, batchSize int) ([]models.OutboxEvent, time.Time, error) {
+	var evs []models.OutboxEvent
+	var until time.Time
+
+	err := s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		// Reset the results, the transaction may be retried
+		evs = nil
+
+		// Step 1: Make sure no other replica is claiming right now
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockKey).
+			Scan(&locked).Error; err != nil {
+			s.l.Errorf(ctx, "service.outbox.claimPending.AdvisoryLock: %v", err)
+			return err
+		}
+
+		if !locked {
+			s.l.Debug(ctx, "service.outbox.claimPending: another relay holds the lock")
+			return nil
+		}
+
+		// Step 2: Leave the outbox to a relay that is still publishing its claim
+		now := time.Now().UTC()
+		var claimed bool
+		if err := tx.Raw("SELECT EXISTS (SELECT 1 FROM outbox_event WHERE published_at IS NULL AND dead_at IS NULL AND claimed_until > ?)", now).
+			Scan(&claimed).Error; err != nil {
+			s.l.Errorf(ctx, "service.outbox.claimPending.CheckClaims: %v", err)
+			return err
+		}
+
+		if claimed {
+			s.l.Debug(ctx, "service.outbox.claimPending: another relay is publishing")
+			return nil
+		}
+
+		// Step 3: Load pending events in insertion order
+		if err := tx.Where("published_at IS NULL AND dead_at IS NULL").
+			Order("id").
+			Limit(batchSize).
+			Find(&evs).Error; err != nil {
+			s.l.Errorf(ctx, "service.outbox.claimPending.FindPending: %v", err)
+			return err
+		}
+
+		if len(evs) == 0 {
+			return nil
+		}
+
+		// Step 4: Claim them
+		until = now.Add(s.claimTimeout)
+		if err := tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", outboxEventIDs(evs)).
+			Update("claimed_until", until).Error; err != nil {
+			s.l.Errorf(ctx, "service.outbox.claimPending.Claim: %v", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return evs, until, nil
+}
+
+// publish sends the batch in one call. When the broker rejects it, events are resent one by one so a
+// single bad event only holds back the events sharing its key, which keeps the per-key order.
+func (s implOutboxService) publish(ctx context.Context, evs []models.OutboxEvent) outboxPublishResult {
+	res := outboxPublishResult{failed: make(map[int64]error)}
+
+	msgs := make([]publisher.Message, len(evs))
+	for i, ev := range evs {
+		msgs[i] = s.buildMessage(ev)
+	}
+
+	err := s.pub.Publish(ctx, msgs...)
+	if err == nil {
+		res.published = outboxEventIDs(evs)
+		return res
+	}
+
+	s.l.Warnf(ctx, "service.outbox.publish: batch of %d rejected, publishing one by one: %v", len(evs), err)
+
+	blocked := make(map[string]bool)
+	for i, ev := range evs {
+		if ctx.Err() != nil {
+			break
+		}
+		if blocked[ev.Key] {
+			continue
+		}
+
+		if err := s.pub.Publish(ctx, msgs[i]); err != nil {
+			if ctx.Err() != nil {
+				// The claim ran out, the rest is published on the next run
+				break
+			}
+			s.l.Errorf(ctx, "service.outbox.publish: event %d (%s): %v", ev.ID, ev.EventType, err)
+			res.failed[ev.ID] = err
+			blocked[ev.Key] = true
+			continue
+		}
+
+		res.published = append(res.published, ev.ID)
+	}
+
+	return res
+}
+
+// settle marks the published events, records the errors of the rejected ones and releases the claim.
+// A rejected event only uses up an attempt when the broker accepted other events of the batch: when
+// nothing got through the broker itself is failing, and giving up on events would lose them.
+// It returns how many events were given up on.
+func (s implOutboxService) settle(ctx context.Context, evs []models.OutboxEvent, res outboxPublishResult) (int, error) {
+	charge := len(res.published) > 0
+	var dead []models.OutboxEvent
+
+	err := s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		// Reset the results, the transaction may be retried
+		dead = nil
+		now := time.Now().UTC()
+
+		// Step 1: Mark the accepted events
+		if len(res.published) > 0 {
+			if err := tx.Model(&models.OutboxEvent{}).
+				Where("id IN ?", res.published).
+				Updates(map[string]any{
+					"published_at":  now,
+					"claimed_until": nil,
+				}).Error; err != nil {
+				s.l.Errorf(ctx, "service.outbox.settle.MarkPublished: %v", err)
+				return err
+			}
+		}
+
+		// Step 2: Record why the rejected events failed, and give up on those out of attempts
+		for _, ev := range evs {
+			pubErr, ok := res.failed[ev.ID]
+			if !ok {
+				continue
+			}
+
+			ups := map[string]any{
+				"last_error":    pubErr.Error(),
+				"claimed_until": nil,
+			}
+			if charge {
+				ups["attempts"] = ev.Attempts + 1
+				if ev.Attempts+1 >= s.maxAttempts {
+					ups["dead_at"] = now
+					dead = append(dead, ev)
+				}
+			}
+
+			if err := tx.Model(&models.OutboxEvent{}).
+				Where("id = ?", ev.ID).
+				Updates(ups).Error; err != nil {
+				s.l.Errorf(ctx, "service.outbox.settle.RecordFailure: %v", err)
+				return err
+			}
+		}
+
+		// Step 3: Release the events that were not attempted
+		if err := tx.Model(&models.OutboxEvent{}).
+			Where("id IN ? AND claimed_until IS NOT NULL", outboxEventIDs(evs)).
+			Update("claimed_until", nil).Error; err != nil {
+			s.l.Errorf(ctx, "service.outbox.settle.ReleaseClaim: %v", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, ev := range dead {
+		s.l.Errorf(ctx, "service.outbox.settle: giving up on event %d (%s, key=%s) after %d attempts: %v",
+			ev.ID, ev.EventType, ev.Key, ev.Attempts+1, res.failed[ev.ID])
+	}
+	metrics.OutboxDeadEvents.Add(float64(len(dead)))
+
+	return len(dead), nil
+}
+
+// DeletePublished deletes up to batchSize events published before the given time.
+// Dead events are kept until someone looks at them.
+func (s implOutboxService) DeletePublished(ctx context.Context, before time.Time, batchSize int) (_ int, err error) {
+	ctx, span := startSpan(ctx, "service.outbox.DeletePublished")
+	defer func() { endSpan(span, err) }()
+
+	ids := s.repo.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Select("id").
+		Where("published_at < ?", before).
+		Order("id").
+		Limit(batchSize)
+
+	result := s.repo.WithContext(ctx).
+		Where("id IN (?)", ids).
+		Delete(&models.OutboxEvent{})
+	if result.Error != nil {
+		s.l.Errorf(ctx, "service.outbox.DeletePublished: %v", result.Error)
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (s implOutboxService) buildMessage(ev models.OutboxEvent) publisher.Message {
+	return publisher.Message{
+		Topic: ev.Topic,
+		Key:   ev.Key,
+		Value: ev.Payload,
+		Headers: map[string]string{
+			"event_id":   strconv.FormatInt(ev.ID, 10),
+			"event_type": ev.EventType,
+		},
+	}
+}
+
+func outboxEventIDs(evs []models.OutboxEvent) []int64 {
+	ids := make([]int64, len(evs))
+	for i, ev := range evs {
+		ids[i] = ev.ID
+	}
+	return ids
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	eventpb "github.com/vogiaan/ticketbottle-inventory/pkg/event"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/util"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+const (
+	// Reservation events are keyed by order code, so all events of an order land on the same partition
+	TopicReservation = "inventory.reservation"
+	// Ticket class events are keyed by ticket class ID
+	TopicTicketClass = "inventory.ticket_class"
+)
+
+const (
+	EventReservationCreated   = "reservation.created"
+	EventReservationConfirmed = "reservation.confirmed"
+	EventReservationCancelled = "reservation.cancelled"
+	EventReservationExpired   = "reservation.expired"
+	EventCapacityChanged      = "ticket_class.capacity_changed"
+)
+
+const (
+	CancelReasonReleased           = "released"
+	CancelReasonTicketClassDeleted = "ticket_class_deleted"
+	CancelReasonEventCancelled     = "event_cancelled"
+	CancelReasonReserveFailed      = "reserve_failed"
+)
+
+// enqueueEvent writes payload to the outbox inside tx, so it is only published if tx commits
+func enqueueEvent(tx *gorm.DB, topic, eventType, key string, payload proto.Message) error {
+	b, err := proto.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&models.OutboxEvent{
+		Topic:     topic,
+		EventType: eventType,
+		Key:       key,
+		Payload:   b,
+	}).Error
+}
+
+func enqueueReservationCreated(tx *gorm.DB, r models.Reservation) error {
+	return enqueueEvent(tx, TopicReservation, EventReservationCreated, r.OrderCode, &eventpb.ReservationCreated{
+		OrderCode: r.OrderCode,
+		Items:     newReservationItems([]models.Reservation{r}),
+		ExpiresAt: util.TimeToISO8601Str(r.ExpiresAt),
+		CreatedAt: util.TimeToISO8601Str(r.CreatedAt),
+	})
+}
+
+func enqueueReservationConfirmed(tx *gorm.DB, oCode string, rs []models.Reservation, at time.Time) error {
+	return enqueueEvent(tx, TopicReservation, EventReservationConfirmed, oCode, &eventpb.ReservationConfirmed{
+		OrderCode:   oCode,
+		Items:       newReservationItems(rs),
+		ConfirmedAt: util.TimeToISO8601Str(at),
+	})
+}
+
+// enqueueReservationsCancelled writes one cancelled event per order found in rs
+func enqueueReservationsCancelled(tx *gorm.DB, rs []models.Reservation, reason string, at time.Time) error {
+	for _, oCode := range sortedOrderCodes(rs) {
+		orderRs := filterByOrderCode(rs, oCode)
+		if err := enqueueEvent(tx, TopicReservation, EventReservationCancelled, oCode, &eventpb.ReservationCancelled{
+			OrderCode:   oCode,
+			Items:       newReservationItems(orderRs),
+			Reason:      reason,
+			CancelledAt: util.TimeToISO8601Str(at),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enqueueReservationsExpired writes one expired event per order found in rs
+func enqueueReservationsExpired(tx *gorm.DB, rs []models.Reservation, at time.Time) error {
+	for _, oCode := range sortedOrderCodes(rs) {
+		orderRs := filterByOrderCode(rs, oCode)
+		if err := enqueueEvent(tx, TopicReservation, EventReservationExpired, oCode, &eventpb.ReservationExpired{
+			OrderCode: oCode,
+			Items:     newReservationItems(orderRs),
+			ExpiredAt: util.TimeToISO8601Str(at),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func enqueueCapacityChanged(tx *gorm.DB, tc models.TicketClass, prevTotal int, at time.Time) error {
+	tcID := strconv.FormatInt(tc.ID, 10)
+	return enqueueEvent(tx, TopicTicketClass, EventCapacityChanged, tcID, &eventpb.CapacityChanged{
+		TicketClassId: tcID,
+		EventId:       tc.EventID,
+		PreviousTotal: int32(prevTotal),
+		Total:         int32(tc.Total),
+		Reserved:      int32(tc.Reserved),
+		Sold:          int32(tc.Sold),
+		Oversold:      int32(tc.Oversold),
+		ChangedAt:     util.TimeToISO8601Str(at),
+	})
+}
+
+// newReservationItems sums quantities per ticket class, ordered by ticket class ID
+func newReservationItems(rs []models.Reservation) []*eventpb.ReservationItem {
+	qtyMap := make(map[int64]int)
+	for _, r := range rs {
+		qtyMap[r.TicketClassID] += r.Qty
+	}
+
+	tcIDs := make([]int64, 0, len(qtyMap))
+	for tcID := range qtyMap {
+		tcIDs = append(tcIDs, tcID)
+	}
+	sort.Slice(tcIDs, func(i, j int) bool { return tcIDs[i] < tcIDs[j] })
+
+	items := make([]*eventpb.ReservationItem, len(tcIDs))
+	for i, tcID := range tcIDs {
+		items[i] = &eventpb.ReservationItem{
+			TicketClassId: strconv.FormatInt(tcID, 10),
+			Quantity:      int32(qtyMap[tcID]),
+		}
+	}
+
+	return items
+}
+
+func sortedOrderCodes(rs []models.Reservation) []string {
+	seen := make(map[string]bool)
+	oCodes := make([]string, 0)
+	for _, r := range rs {
+		if !seen[r.OrderCode] {
+			seen[r.OrderCode] = true
+			oCodes = append(oCodes, r.OrderCode)
+		}
+	}
+	sort.Strings(oCodes)
+
+	return oCodes
+}
+
+func filterByOrderCode(rs []models.Reservation, oCode string) []models.Reservation {
+	out := make([]models.Reservation, 0)
+	for _, r := range rs {
+		if r.OrderCode == oCode {
+			out = append(out, r)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// counterQuery recomputes reserved and sold from the reservations of each ticket class.
+// It runs as one statement, so the counters and the reservations come from the same snapshot.
+const counterQuery = `
+	SELECT tc.id AS ticket_class_id, tc.event_id, tc.reserved, tc.sold,
+		COALESCE(SUM(r.qty) FILTER (WHERE r.status = @active), 0) AS expected_reserved,
+		COALESCE(SUM(r.qty) FILTER (WHERE r.status = @confirmed), 0) AS expected_sold
+	FROM ticket_class tc
+	LEFT JOIN reservation r ON r.ticket_class_id = tc.id
+	WHERE tc.deleted_at IS NULL %s
+	GROUP BY tc.id
+	HAVING tc.reserved <> COALESCE(SUM(r.qty) FILTER (WHERE r.status = @active), 0)
+		OR tc.sold <> COALESCE(SUM(r.qty) FILTER (WHERE r.status = @confirmed), 0)
+	ORDER BY tc.id`
+
+// notValidChecksQuery lists the inventory check constraints that were added NOT VALID and are not validated yet
+const notValidChecksQuery = `
+	SELECT t.relname AS table_name, c.conname AS name
+	FROM pg_constraint c
+	JOIN pg_class t ON t.oid = c.conrelid
+	WHERE c.conrelid IN (to_regclass('ticket_class'), to_regclass('reservation'))
+		AND c.contype = 'c'
+		AND NOT c.convalidated
+	ORDER BY c.conname`
+
+type ReconciliationService interface {
+	Reconcile(ctx context.Context, in ReconcileInput) (ReconcileOutput, error)
+}
+
+type implReconciliationService struct {
+	l    pkgLog.Logger
+	repo *pkgGorm.Repository
+	bus  availability.Publisher
+}
+
+func NewReconciliationService(l pkgLog.Logger, repo *pkgGorm.Repository, bus availability.Publisher) ReconciliationService {
+	return &implReconciliationService{
+		l:    l,
+		repo: repo,
+		bus:  bus,
+	}
+}
+
+// Reconcile compares the reserved and sold counters of every ticket class with its reservations.
+// Outside dry-run mode each mismatch is checked again under the ticket class lock and then repaired.
+// A class that cannot be repaired does not stop the run, its error is joined into the returned one
+// and the output still reports every mismatch found. Once no drift is left, check constraints that
+// migrations left NOT VALID are validated.
+func (s implReconciliationService) Reconcile(ctx context.Context, in ReconcileInput) (_ ReconcileOutput, err error) {
+	ctx, span := startSpan(ctx, "service.reconciliation.Reconcile")
+	defer func() { endSpan(span, err) }()
+
+	// Step 1: Find drifted ticket classes without taking any lock
+	rows, err := s.findMismatches(s.repo.WithContext(ctx), nil)
+	if err != nil {
+		s.l.Errorf(ctx, "service.reconciliation.Reconcile.findMismatches: %v", err)
+		return ReconcileOutput{}, err
+	}
+
+	out := ReconcileOutput{
+		Mismatches: make([]CounterMismatch, 0, len(rows)),
+	}
+
+	// Step 2: Report and optionally repair them one class at a time
+	var errs []error
+	for _, row := range rows {
+		s.l.Warnf(ctx, "service.reconciliation.Reconcile: ticket_class_id=%d event_id=%s reserved=%d (expected %d), sold=%d (expected %d)",
+			row.TicketClassID, row.EventID, row.Reserved, row.ExpectedReserved, row.Sold, row.ExpectedSold)
+
+		m := CounterMismatch{
+			TicketClassID:    row.TicketClassID,
+			EventID:          row.EventID,
+			Reserved:         row.Reserved,
+			ExpectedReserved: row.ExpectedReserved,
+			Sold:             row.Sold,
+			ExpectedSold:     row.ExpectedSold,
+		}
+		// Once cancelled every fix would fail the same way, the remaining classes are only reported
+		if !in.DryRun && ctx.Err() == nil {
+			fixed, err := s.fix(ctx, row.TicketClassID)
+			if err != nil {
+				s.l.Errorf(ctx, "service.reconciliation.Reconcile.fix: ticket_class_id=%d: %v", row.TicketClassID, err)
+				errs = append(errs, fmt.Errorf("ticket_class_id=%d: %w", row.TicketClassID, err))
+			}
+			m.Fixed = fixed
+			if fixed {
+				out.Fixed++
+			}
+		}
+
+		out.Mismatches = append(out.Mismatches, m)
+	}
+
+	if len(rows) > 0 {
+		s.l.Warnf(ctx, "service.reconciliation.Reconcile: found %d mismatched ticket classes, fixed %d, failed %d (dry_run=%t)",
+			len(rows), out.Fixed, len(errs), in.DryRun)
+	}
+
+	if err := ctx.Err(); err != nil && !in.DryRun {
+		errs = append(errs, err)
+	}
+
+	// Step 3: Validate the constraints drifted counters kept from being validated
+	if out.Fixed == len(rows) && len(errs) == 0 {
+		if err := s.validateChecks(ctx); err != nil {
+			s.l.Errorf(ctx, "service.reconciliation.Reconcile.validateChecks: %v", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return out, errors.Join(errs...)
+}
+
+// validateChecks validates the NOT VALID check constraints. Rows that still violate one, e.g. an
+// unknown status that reconciliation does not repair, leave it unvalidated until the next run.
+func (s implReconciliationService) validateChecks(ctx context.Context) error {
+	var checks []checkConstraintRow
+	if err := s.repo.WithContext(ctx).Raw(notValidChecksQuery).Scan(&checks).Error; err != nil {
+		return err
+	}
+
+	for _, c := range checks {
+		err := s.repo.WithContext(ctx).
+			Exec("ALTER TABLE ? VALIDATE CONSTRAINT ?", clause.Table{Name: c.TableName}, clause.Column{Name: c.Name}).Error
+		if errors.Is(err, pkgGorm.ErrCheckViolation) {
+			s.l.Warnf(ctx, "service.reconciliation.validateChecks: %s.%s is still violated: %v", c.TableName, c.Name, err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		s.l.Infof(ctx, "service.reconciliation.validateChecks: validated %s.%s", c.TableName, c.Name)
+	}
+
+	return nil
+}
+
+// fix locks the ticket class, recomputes its counters and overwrites them.
+// Every writer updates the ticket class row in the same transaction as its reservations,
+// so while the lock is held the reservations cannot move. It reports false if the drift was gone.
+func (s implReconciliationService) fix(ctx context.Context, tcID int64) (bool, error) {
+	fixed := false
+
+	err := s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		fixed = false
+
+		// Step 1: Lock the ticket class row
+		var tc models.TicketClass
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&tc, tcID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		// Step 2: Recompute under the lock, a transaction in flight may have settled the drift
+		rows, err := s.findMismatches(tx, &tcID)
+		if err != nil {
+			return err
+		}
+
+		if len(rows) == 0 {
+			return nil
+		}
+
+		// Step 3: Overwrite the counters with the recomputed values
+		if err := tx.Model(&models.TicketClass{}).
+			Where("id = ?", tcID).
+			Updates(map[string]any{
+				"reserved": rows[0].ExpectedReserved,
+				"sold":     rows[0].ExpectedSold,
+			}).Error; err != nil {
+			return err
+		}
+
+		s.l.Warnf(ctx, "service.reconciliation.fix: ticket_class_id=%d reserved %d -> %d, sold %d -> %d",
+			tcID, rows[0].Reserved, rows[0].ExpectedReserved, rows[0].Sold, rows[0].ExpectedSold)
+
+		fixed = true
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	if fixed {
+		publishAvailability(ctx, s.bus, tcID)
+	}
+
+	return fixed, nil
+}
+
+// findMismatches runs counterQuery, limited to a single ticket class when tcID is set
+func (s implReconciliationService) findMismatches(db *gorm.DB, tcID *int64) ([]counterRow, error) {
+	args := map[string]any{
+		"active":    models.ReservationStatusActive,
+		"confirmed": models.ReservationStatusConfirmed,
+	}
+
+	filter := ""
+	if tcID != nil {
+		filter = "AND tc.id = @id"
+		args["id"] = *tcID
+	}
+
+	var rows []counterRow
+	if err := db.Raw(fmt.Sprintf(counterQuery, filter), args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
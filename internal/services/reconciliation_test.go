@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+func TestReconcileValidatesChecksWithoutDrift(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	s := implReconciliationService{l: pkgLog.InitializeTestZapLogger(), repo: repo}
+
+	mock.ExpectQuery(`SELECT tc.id AS ticket_class_id`).
+		WillReturnRows(sqlmock.NewRows([]string{"ticket_class_id", "event_id", "reserved", "sold", "expected_reserved", "expected_sold"}))
+	mock.ExpectQuery(`SELECT t.relname AS table_name, c.conname AS name FROM pg_constraint`).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "name"}).AddRow("ticket_class", "chk_ticket_class_capacity"))
+	mock.ExpectExec(`ALTER TABLE "ticket_class" VALIDATE CONSTRAINT "chk_ticket_class_capacity"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	out, err := s.Reconcile(context.Background(), ReconcileInput{DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(out.Mismatches) != 0 {
+		t.Errorf("Reconcile() found %d mismatches, want none", len(out.Mismatches))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReconcileSkipsValidationWithDrift(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	s := implReconciliationService{l: pkgLog.InitializeTestZapLogger(), repo: repo}
+
+	// In dry-run mode the drift stays, validating would fail on it
+	mock.ExpectQuery(`SELECT tc.id AS ticket_class_id`).
+		WillReturnRows(sqlmock.NewRows([]string{"ticket_class_id", "event_id", "reserved", "sold", "expected_reserved", "expected_sold"}).
+			AddRow(1, "evt-1", 5, 0, 3, 0))
+
+	out, err := s.Reconcile(context.Background(), ReconcileInput{DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(out.Mismatches) != 1 {
+		t.Errorf("Reconcile() found %d mismatches, want 1", len(out.Mismatches))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
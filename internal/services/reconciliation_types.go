@@ -0,0 +1,39 @@
+package service
+
+type ReconcileInput struct {
+	// DryRun only reports mismatches, the counters are left untouched
+	DryRun bool
+}
+
+type ReconcileOutput struct {
+	Mismatches []CounterMismatch
+	Fixed      int
+}
+
+// CounterMismatch is a ticket class whose counters disagree with its reservations.
+// ExpectedReserved sums ACTIVE holds and ExpectedSold sums CONFIRMED ones.
+type CounterMismatch struct {
+	TicketClassID    int64
+	EventID          string
+	Reserved         int
+	ExpectedReserved int
+	Sold             int
+	ExpectedSold     int
+	Fixed            bool
+}
+
+// checkConstraintRow is a check constraint that is not validated yet
+type checkConstraintRow struct {
+	TableName string
+	Name      string
+}
+
+// counterRow is a ticket class with the counters recomputed from its reservations
+type counterRow struct {
+	TicketClassID    int64
+	EventID          string
+	Reserved         int
+	ExpectedReserved int
+	Sold             int
+	ExpectedSold     int
+}
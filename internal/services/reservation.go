@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"sync"
 	"time"
 
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	"github.com/vogiaan/ticketbottle-inventory/internal/domain"
+	"github.com/vogiaan/ticketbottle-inventory/internal/metrics"
 	"github.com/vogiaan/ticketbottle-inventory/internal/models"
 	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
 	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
@@ -12,82 +17,195 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// ExpiryNotifier is told when every new hold expires, so the expiry worker can wake up in time
+type ExpiryNotifier interface {
+	NotifyExpiry(at time.Time)
+}
+
 type implReservationService struct {
 	l    pkgLog.Logger
 	repo *pkgGorm.Repository
+	bus  availability.Publisher
+	exp  ExpiryNotifier
 }
 
 type ReservationService interface {
 	Reserve(ctx context.Context, in ReserveInput) error
 	Confirm(ctx context.Context, oCode string) error
 	Release(ctx context.Context, oCode string) error
+	ProcessOrderEvent(ctx context.Context, in ProcessOrderEventInput) error
+	RecordFailedOrderEvent(ctx context.Context, in ProcessOrderEventInput, cause error) error
 	UpdateStatus(ctx context.Context, id uint, status models.ReservationStatus) error
 	UpdateStatusByOrderCode(ctx context.Context, oCode string, status models.ReservationStatus) error
 	BatchExpireReservations(ctx context.Context, batchSize int) (int, error)
+	NextExpiry(ctx context.Context) (*time.Time, error)
 	Delete(ctx context.Context, id uint) error
 	DeleteByOrderCode(ctx context.Context, oCode string) error
 }
 
-func NewReservationService(l pkgLog.Logger, repo *pkgGorm.Repository) ReservationService {
+func NewReservationService(l pkgLog.Logger, repo *pkgGorm.Repository, bus availability.Publisher, exp ExpiryNotifier) ReservationService {
 	return &implReservationService{
 		l:    l,
 		repo: repo,
+		bus:  bus,
+		exp:  exp,
 	}
 }
 
-func (s implReservationService) Reserve(ctx context.Context, in ReserveInput) error {
+func (s implReservationService) Reserve(ctx context.Context, in ReserveInput) (err error) {
+	ctx, span := startSpan(ctx, "service.reservation.Reserve", attrOrderCode.String(in.OrderCode))
+	defer func() { endSpan(span, err) }()
 
 	wg := sync.WaitGroup{}
+	mu := sync.Mutex{}
 	var wgErr error
+	var created []models.Reservation
 
-	for _, item := range in.Items {
+	// Duplicate ticket classes become one hold so the per-order limit sees the whole quantity
+	for _, item := range s.combineItems(in.Items) {
 		wg.Add(1)
 		go func(item ReserveItem) {
 			defer wg.Done()
-			_, err := s.Create(ctx, in.OrderCode, in.ExpiresAt, item)
+			r, err := s.Create(ctx, in.OrderCode, in.ExpiresAt, item)
+
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
 				s.l.Errorf(ctx, "service.reservation.Reserve: %v", err)
-				wgErr = err
+				if wgErr == nil {
+					wgErr = err
+				}
+				return
 			}
+			created = append(created, r)
 		}(item)
 	}
 
 	wg.Wait()
 	if wgErr != nil {
 		s.l.Errorf(ctx, "service.reservation.Reserve: %v", wgErr)
-		s.DeleteByOrderCode(ctx, in.OrderCode)
+		// Only undo the holds taken by this call, holds the order already had are left alone
+		if err := s.rollbackHolds(ctx, created); err != nil {
+			s.l.Errorf(ctx, "service.reservation.Reserve.rollbackHolds: %v", err)
+		}
 		return wgErr
 	}
 
 	return nil
 }
 
-func (s implReservationService) Create(ctx context.Context, oCode string, expAt time.Time, item ReserveItem) (models.Reservation, error) {
+// rollbackHolds gives back the quantity of holds taken by a failed Reserve and deletes them,
+// so the order can retry with the same order code
+func (s implReservationService) rollbackHolds(ctx context.Context, rs []models.Reservation) error {
+	if len(rs) == 0 {
+		return nil
+	}
+
+	rIDs := make([]int64, len(rs))
+	for i, r := range rs {
+		rIDs[i] = r.ID
+	}
+
+	var tcIDs []int64
+	var cancelled []models.Reservation
+
+	err := s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		cancelled = nil
+
+		// Step 1: Lock the holds that are still active, the expiry worker may have released some already
+		var active []models.Reservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND status = ?", rIDs, models.ReservationStatusActive).
+			Order("id").
+			Find(&active).Error; err != nil {
+			return err
+		}
+
+		tcUps := make(map[int64]int)
+		for _, r := range active {
+			tcUps[r.TicketClassID] += r.Qty
+		}
+
+		// Step 2: Give the quantity back in id order
+		tcIDs = sortedTicketClassIDs(tcUps)
+		for _, tcID := range tcIDs {
+			qty := tcUps[tcID]
+			result := tx.Model(&models.TicketClass{}).
+				Where("id = ?", tcID).
+				Where("reserved >= ?", qty). // Safety check
+				Update("reserved", gorm.Expr("reserved - ?", qty))
+
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				s.l.Errorf(ctx, "service.reservation.rollbackHolds: insufficient reserved tickets for ticket_class_id=%d (needed=%d)", tcID, qty)
+				return ErrCounterMismatch.WithInt(domain.MetaTicketClassID, tcID)
+			}
+		}
+
+		// Step 3: Delete the holds and tell consumers the created events are void
+		if err := tx.Where("id IN ?", rIDs).Delete(&models.Reservation{}).Error; err != nil {
+			return err
+		}
+
+		if len(active) == 0 {
+			return nil
+		}
+
+		cancelled = active
+		return enqueueReservationsCancelled(tx, active, CancelReasonReserveFailed, time.Now().UTC())
+	})
+
+	if err != nil {
+		return err
+	}
+
+	publishAvailability(ctx, s.bus, tcIDs...)
+	recordReservations(metrics.ReservationCancelled, cancelled...)
+
+	return nil
+}
+
+func (s implReservationService) Create(ctx context.Context, oCode string, expAt time.Time, item ReserveItem) (_ models.Reservation, err error) {
+	ctx, span := startSpan(ctx, "service.reservation.Create", attrOrderCode.String(oCode), attrTicketClassID.Int64(item.TicketClassID))
+	defer func() { endSpan(span, err) }()
+
 	var r models.Reservation
 
-	err := s.repo.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
 		// Step 1: Lock the ticket class row for update
 		var ticketClass models.TicketClass
 		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 			First(&ticketClass, item.TicketClassID).Error; err != nil {
 			s.l.Errorf(ctx, "service.reservation.Create.LockTicketClass: %v", err)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTicketClassNotFound.WithInt(domain.MetaTicketClassID, item.TicketClassID)
+			}
 			return err
 		}
 
-		// Step 2: Check if enough stock available
-		requestedQty := item.Qty
-		availableQty := ticketClass.Total - ticketClass.Reserved - ticketClass.Sold
+		// Step 2: Check if the ticket class is on sale and enough stock is available
+		var paused int64
+		if err := tx.Model(&models.EventSalesPause{}).
+			Where("event_id = ?", ticketClass.EventID).
+			Count(&paused).Error; err != nil {
+			s.l.Errorf(ctx, "service.reservation.Create.CheckSalesPause: %v", err)
+			return err
+		}
 
-		if availableQty < requestedQty {
-			s.l.Warnf(ctx, "service.reservation.Create: insufficient stock for ticket_class_id=%d (available=%d, requested=%d)",
-				item.TicketClassID, availableQty, requestedQty)
-			return gorm.ErrInvalidData
+		requestedQty := item.Qty
+		if reason := checkSellable(ticketClass, paused > 0, requestedQty, time.Now().UTC()); reason != AvailabilityReasonOK {
+			s.l.Warnf(ctx, "service.reservation.Create: ticket_class_id=%d rejected (reason=%s, available=%d, requested=%d)",
+				item.TicketClassID, reason, ticketClass.AvailableQty(), requestedQty)
+			return reason.err(ticketClass, requestedQty)
 		}
 
 		// Alternative check: reserved + sold + qty <= total
 		if ticketClass.Reserved+ticketClass.Sold+requestedQty > ticketClass.Total {
 			s.l.Warnf(ctx, "service.reservation.Create: would exceed total capacity for ticket_class_id=%d", item.TicketClassID)
-			return gorm.ErrInvalidData
+			return AvailabilityReasonInsufficient.err(ticketClass, requestedQty)
 		}
 
 		// Step 3: Update ticket class counters (increment reserved)
@@ -102,13 +220,22 @@ func (s implReservationService) Create(ctx context.Context, oCode string, expAt
 
 		if result.RowsAffected == 0 {
 			s.l.Errorf(ctx, "service.reservation.Create: failed to update ticket_class_id=%d", item.TicketClassID)
-			return gorm.ErrInvalidData
+			return ErrCounterMismatch.WithInt(domain.MetaTicketClassID, item.TicketClassID)
 		}
 
 		// Step 4: Build and insert the reservation record
 		r = s.buildModel(oCode, expAt, item)
 		if err := tx.Create(&r).Error; err != nil {
 			s.l.Errorf(ctx, "service.reservation.Create.InsertReservation: %v", err)
+			if errors.Is(err, pkgGorm.ErrUniqueViolation) {
+				return ErrReservationExists.With(domain.MetaOrderCode, oCode).WithInt(domain.MetaTicketClassID, item.TicketClassID)
+			}
+			return err
+		}
+
+		// Step 5: Record the reservation.created event
+		if err := enqueueReservationCreated(tx, r); err != nil {
+			s.l.Errorf(ctx, "service.reservation.Create.EnqueueEvent: %v", err)
 			return err
 		}
 
@@ -122,10 +249,17 @@ func (s implReservationService) Create(ctx context.Context, oCode string, expAt
 		return models.Reservation{}, err
 	}
 
+	publishAvailability(ctx, s.bus, r.TicketClassID)
+	s.exp.NotifyExpiry(r.ExpiresAt)
+	recordReservations(metrics.ReservationCreated, r)
+
 	return r, nil
 }
 
-func (s implReservationService) GetByOrderCode(ctx context.Context, oCode string) ([]models.Reservation, error) {
+func (s implReservationService) GetByOrderCode(ctx context.Context, oCode string) (_ []models.Reservation, err error) {
+	ctx, span := startSpan(ctx, "service.reservation.GetByOrderCode", attrOrderCode.String(oCode))
+	defer func() { endSpan(span, err) }()
+
 	var rs []models.Reservation
 	if err := s.repo.WithContext(ctx).Where("order_code = ?", oCode).Find(&rs).Error; err != nil {
 		s.l.Errorf(ctx, "service.reservation.GetByOrderCode: %v", err)
@@ -135,7 +269,10 @@ func (s implReservationService) GetByOrderCode(ctx context.Context, oCode string
 	return rs, nil
 }
 
-func (s implReservationService) GetActiveByTicketClassID(ctx context.Context, ticketClassID uint) ([]models.Reservation, error) {
+func (s implReservationService) GetActiveByTicketClassID(ctx context.Context, ticketClassID uint) (_ []models.Reservation, err error) {
+	ctx, span := startSpan(ctx, "service.reservation.GetActiveByTicketClassID", attrTicketClassID.Int64(int64(ticketClassID)))
+	defer func() { endSpan(span, err) }()
+
 	var rs []models.Reservation
 	now := time.Now().UTC()
 	if err := s.repo.WithContext(ctx).
@@ -149,7 +286,10 @@ func (s implReservationService) GetActiveByTicketClassID(ctx context.Context, ti
 	return rs, nil
 }
 
-func (s implReservationService) GetExpired(ctx context.Context, limit int) ([]models.Reservation, error) {
+func (s implReservationService) GetExpired(ctx context.Context, limit int) (_ []models.Reservation, err error) {
+	ctx, span := startSpan(ctx, "service.reservation.GetExpired")
+	defer func() { endSpan(span, err) }()
+
 	var rs []models.Reservation
 	now := time.Now().UTC()
 	if err := s.repo.WithContext(ctx).
@@ -163,10 +303,13 @@ func (s implReservationService) GetExpired(ctx context.Context, limit int) ([]mo
 	return rs, nil
 }
 
-func (s implReservationService) UpdateStatus(ctx context.Context, id uint, status models.ReservationStatus) error {
+func (s implReservationService) UpdateStatus(ctx context.Context, id uint, status models.ReservationStatus) (err error) {
+	ctx, span := startSpan(ctx, "service.reservation.UpdateStatus")
+	defer func() { endSpan(span, err) }()
+
 	var r models.Reservation
 	if err := s.repo.FindByID(ctx, &r, id); err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.l.Warnf(ctx, "service.reservation.UpdateStatus: %v", err)
 		}
 		s.l.Errorf(ctx, "service.reservation.UpdateStatus: %v", err)
@@ -182,7 +325,10 @@ func (s implReservationService) UpdateStatus(ctx context.Context, id uint, statu
 	return nil
 }
 
-func (s implReservationService) UpdateStatusByOrderCode(ctx context.Context, oCode string, status models.ReservationStatus) error {
+func (s implReservationService) UpdateStatusByOrderCode(ctx context.Context, oCode string, status models.ReservationStatus) (err error) {
+	ctx, span := startSpan(ctx, "service.reservation.UpdateStatusByOrderCode", attrOrderCode.String(oCode))
+	defer func() { endSpan(span, err) }()
+
 	if err := s.repo.WithContext(ctx).Model(&models.Reservation{}).
 		Where("order_code = ?", oCode).
 		Update("status", status).Error; err != nil {
@@ -193,26 +339,40 @@ func (s implReservationService) UpdateStatusByOrderCode(ctx context.Context, oCo
 	return nil
 }
 
-func (s implReservationService) Confirm(ctx context.Context, oCode string) error {
-	err := s.repo.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return s.confirmReservationTx(ctx, tx, oCode)
+func (s implReservationService) Confirm(ctx context.Context, oCode string) (err error) {
+	ctx, span := startSpan(ctx, "service.reservation.Confirm", attrOrderCode.String(oCode))
+	defer func() { endSpan(span, err) }()
+
+	var rs []models.Reservation
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		rs, err = s.confirmReservationTx(ctx, tx, oCode)
+		return err
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	publishAvailability(ctx, s.bus, reservationTicketClassIDs(rs)...)
+	recordReservations(metrics.ReservationConfirmed, rs...)
+	return nil
 }
 
-func (s implReservationService) confirmReservationTx(ctx context.Context, tx *gorm.DB, oCode string) error {
+// confirmReservationTx returns the reservations it confirmed
+func (s implReservationService) confirmReservationTx(ctx context.Context, tx *gorm.DB, oCode string) ([]models.Reservation, error) {
 	// Step 1: Lock and fetch all reservations for this order
 	var rs []models.Reservation
 	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("order_code = ?", oCode).
+		Order("id").
 		Find(&rs).Error; err != nil {
 		s.l.Errorf(ctx, "service.reservation.ConfirmReservation.LockReservations: %v", err)
-		return err
+		return nil, err
 	}
 
 	if len(rs) == 0 {
 		s.l.Warnf(ctx, "service.reservation.ConfirmReservation: no reservations found for order_code=%s", oCode)
-		return gorm.ErrRecordNotFound
+		return nil, ErrReservationNotFound.With(domain.MetaOrderCode, oCode)
 	}
 
 	now := time.Now().UTC()
@@ -224,21 +384,22 @@ func (s implReservationService) confirmReservationTx(ctx context.Context, tx *go
 		// Validate reservation is active
 		if r.Status != models.ReservationStatusActive {
 			s.l.Warnf(ctx, "service.reservation.ConfirmReservation: reservation %d is not active (status=%s)", r.ID, r.Status)
-			return gorm.ErrInvalidData
+			return nil, s.notActiveError(r)
 		}
 
 		// Check if reservation has expired
 		if now.After(r.ExpiresAt) {
 			s.l.Warnf(ctx, "service.reservation.ConfirmReservation: reservation %d has expired", r.ID)
-			return gorm.ErrInvalidData
+			return nil, ErrReservationExpired.With(domain.MetaOrderCode, oCode).WithInt(domain.MetaReservationID, r.ID)
 		}
 
 		tcUps[r.TicketClassID] += r.Qty
 		rIDs = append(rIDs, r.ID)
 	}
 
-	// Step 3: Update ticket class counters (reserved → sold) grouped by ticket_class_id
-	for tcID, qty := range tcUps {
+	// Step 3: Update ticket class counters (reserved → sold) grouped by ticket_class_id, in id order
+	for _, tcID := range sortedTicketClassIDs(tcUps) {
+		qty := tcUps[tcID]
 		result := tx.Model(&models.TicketClass{}).
 			Where("id = ?", tcID).
 			Where("reserved >= ?", qty).
@@ -249,13 +410,13 @@ func (s implReservationService) confirmReservationTx(ctx context.Context, tx *go
 
 		if result.Error != nil {
 			s.l.Errorf(ctx, "service.reservation.ConfirmReservation.UpdateTicketClass: ticket_class_id=%d, error=%v", tcID, result.Error)
-			return result.Error
+			return nil, result.Error
 		}
 
 		// Check if the ticket class was actually updated (reserved >= qty condition)
 		if result.RowsAffected == 0 {
 			s.l.Errorf(ctx, "service.reservation.ConfirmReservation: insufficient reserved tickets for ticket_class_id=%d (needed=%d)", tcID, qty)
-			return gorm.ErrInvalidData
+			return nil, ErrCounterMismatch.WithInt(domain.MetaTicketClassID, tcID)
 		}
 
 		s.l.Infof(ctx, "service.reservation.ConfirmReservation: moved %d tickets from reserved to sold for ticket_class_id=%d", qty, tcID)
@@ -268,33 +429,143 @@ func (s implReservationService) confirmReservationTx(ctx context.Context, tx *go
 
 	if result.Error != nil {
 		s.l.Errorf(ctx, "service.reservation.ConfirmReservation.UpdateReservations: %v", result.Error)
-		return result.Error
+		return nil, result.Error
+	}
+
+	// Step 5: Record the reservation.confirmed event
+	if err := enqueueReservationConfirmed(tx, oCode, rs, now); err != nil {
+		s.l.Errorf(ctx, "service.reservation.ConfirmReservation.EnqueueEvent: %v", err)
+		return nil, err
 	}
 
 	s.l.Infof(ctx, "successfully confirmed %d reservations for order_code=%s", len(rs), oCode)
+	return rs, nil
+}
+
+func (s implReservationService) Release(ctx context.Context, oCode string) (err error) {
+	ctx, span := startSpan(ctx, "service.reservation.Release", attrOrderCode.String(oCode))
+	defer func() { endSpan(span, err) }()
+
+	var rs []models.Reservation
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		rs, err = s.cancelReservationTx(ctx, tx, oCode)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	publishAvailability(ctx, s.bus, reservationTicketClassIDs(rs)...)
+	recordReservations(metrics.ReservationCancelled, rs...)
 	return nil
 }
 
-func (s implReservationService) Release(ctx context.Context, oCode string) error {
-	err := s.repo.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return s.cancelReservationTx(ctx, tx, oCode)
+// ProcessOrderEvent applies an order service event exactly once.
+// Paid orders are confirmed, cancelled and failed orders are released. The processed message
+// is stored in the same transaction, so a redelivered message is ignored.
+func (s implReservationService) ProcessOrderEvent(ctx context.Context, in ProcessOrderEventInput) (err error) {
+	ctx, span := startSpan(ctx, "service.reservation.ProcessOrderEvent", attrOrderCode.String(in.OrderCode), attrOrderEvent.String(string(in.Type)))
+	defer func() { endSpan(span, err) }()
+
+	duplicate := false
+	var rs []models.Reservation
+	outcome := metrics.ReservationConfirmed
+
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		// Step 1: Claim the message, a conflict means it was handled before
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(s.buildProcessedMessage(in, models.ProcessedMessageOutcomeApplied, nil))
+		if result.Error != nil {
+			s.l.Errorf(ctx, "service.reservation.ProcessOrderEvent.InsertProcessedMessage: %v", result.Error)
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			duplicate = true
+			return nil
+		}
+
+		// Step 2: Apply the event to the reservations of the order
+		var err error
+		switch in.Type {
+		case OrderEventPaid:
+			rs, err = s.confirmReservationTx(ctx, tx, in.OrderCode)
+		case OrderEventCancelled, OrderEventFailed:
+			rs, err = s.cancelReservationTx(ctx, tx, in.OrderCode)
+			outcome = metrics.ReservationCancelled
+		default:
+			err = ErrUnknownOrderEvent
+		}
+		return err
 	})
+
+	if duplicate {
+		s.l.Infof(ctx, "service.reservation.ProcessOrderEvent: message %s was already processed", in.MessageID)
+		return nil
+	}
+
+	if err == nil {
+		publishAvailability(ctx, s.bus, reservationTicketClassIDs(rs)...)
+		recordReservations(outcome, rs...)
+		return nil
+	}
+
+	// The reservations are not in a state this event applies to (already confirmed, released or gone).
+	// Retrying cannot change that, so the message is recorded as skipped. Counter drift is not one of
+	// these: the order still has to be applied, so the message is retried until the counters are repaired
+	// or the consumer gives up on it with RecordFailedOrderEvent.
+	if errors.Is(err, ErrReservationNotFound) ||
+		errors.Is(err, ErrReservationNotActive) ||
+		errors.Is(err, ErrReservationExpired) ||
+		errors.Is(err, ErrUnknownOrderEvent) {
+		s.l.Warnf(ctx, "service.reservation.ProcessOrderEvent: skipping %s for order_code=%s: %v", in.Type, in.OrderCode, err)
+		if err := s.repo.WithContext(ctx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(s.buildProcessedMessage(in, models.ProcessedMessageOutcomeSkipped, err)).Error; err != nil {
+			s.l.Errorf(ctx, "service.reservation.ProcessOrderEvent.InsertProcessedMessage: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	s.l.Errorf(ctx, "service.reservation.ProcessOrderEvent: %v", err)
 	return err
 }
 
-func (s implReservationService) cancelReservationTx(ctx context.Context, tx *gorm.DB, oCode string) error {
+// RecordFailedOrderEvent marks a message the consumer gave up on as FAILED, so it is acknowledged and
+// redeliveries are ignored. The order's reservations are left as they are for manual repair.
+func (s implReservationService) RecordFailedOrderEvent(ctx context.Context, in ProcessOrderEventInput, cause error) (err error) {
+	ctx, span := startSpan(ctx, "service.reservation.RecordFailedOrderEvent", attrOrderCode.String(in.OrderCode), attrOrderEvent.String(string(in.Type)))
+	defer func() { endSpan(span, err) }()
+
+	if err := s.repo.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(s.buildProcessedMessage(in, models.ProcessedMessageOutcomeFailed, cause)).Error; err != nil {
+		s.l.Errorf(ctx, "service.reservation.RecordFailedOrderEvent.InsertProcessedMessage: %v", err)
+		return err
+	}
+
+	metrics.OrderEventsFailed.Inc()
+	s.l.Errorf(ctx, "service.reservation.RecordFailedOrderEvent: gave up on %s for order_code=%s (message %s): %v", in.Type, in.OrderCode, in.MessageID, cause)
+	return nil
+}
+
+// cancelReservationTx returns the reservations it cancelled
+func (s implReservationService) cancelReservationTx(ctx context.Context, tx *gorm.DB, oCode string) ([]models.Reservation, error) {
 	// Step 1: Lock and fetch all reservations for this order
 	var rs []models.Reservation
 	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("order_code = ?", oCode).
+		Order("id").
 		Find(&rs).Error; err != nil {
 		s.l.Errorf(ctx, "service.reservation.CancelReservation.LockReservations: %v", err)
-		return err
+		return nil, err
 	}
 
 	if len(rs) == 0 {
 		s.l.Warnf(ctx, "service.reservation.CancelReservation: no reservations found for order_code=%s", oCode)
-		return gorm.ErrRecordNotFound
+		return nil, ErrReservationNotFound.With(domain.MetaOrderCode, oCode)
 	}
 
 	tcUps := make(map[int64]int) // ticket_class_id -> qty to release from reserved
@@ -305,15 +576,16 @@ func (s implReservationService) cancelReservationTx(ctx context.Context, tx *gor
 		// Validate reservation can be cancelled (must be ACTIVE)
 		if r.Status != models.ReservationStatusActive {
 			s.l.Warnf(ctx, "service.reservation.CancelReservation: reservation %d is not active (status=%s)", r.ID, r.Status)
-			return gorm.ErrInvalidData
+			return nil, s.notActiveError(r)
 		}
 
 		tcUps[r.TicketClassID] += r.Qty
 		rIDs = append(rIDs, r.ID)
 	}
 
-	// Step 3: Update ticket class counters (decrement reserved) grouped by ticket_class_id
-	for tcID, qty := range tcUps {
+	// Step 3: Update ticket class counters (decrement reserved) grouped by ticket_class_id, in id order
+	for _, tcID := range sortedTicketClassIDs(tcUps) {
+		qty := tcUps[tcID]
 		result := tx.Model(&models.TicketClass{}).
 			Where("id = ?", tcID).
 			Where("reserved >= ?", qty). // Safety check
@@ -321,13 +593,13 @@ func (s implReservationService) cancelReservationTx(ctx context.Context, tx *gor
 
 		if result.Error != nil {
 			s.l.Errorf(ctx, "service.reservation.CancelReservation.DecrementReserved: ticket_class_id=%d, error=%v", tcID, result.Error)
-			return result.Error
+			return nil, result.Error
 		}
 
 		// Check if the ticket class was actually updated
 		if result.RowsAffected == 0 {
 			s.l.Errorf(ctx, "service.reservation.CancelReservation: insufficient reserved tickets for ticket_class_id=%d (needed=%d)", tcID, qty)
-			return gorm.ErrInvalidData
+			return nil, ErrCounterMismatch.WithInt(domain.MetaTicketClassID, tcID)
 		}
 
 		s.l.Infof(ctx, "service.reservation.CancelReservation: released %d reserved tickets for ticket_class_id=%d", qty, tcID)
@@ -340,28 +612,37 @@ func (s implReservationService) cancelReservationTx(ctx context.Context, tx *gor
 
 	if result.Error != nil {
 		s.l.Errorf(ctx, "service.reservation.CancelReservation.UpdateReservations: %v", result.Error)
-		return result.Error
+		return nil, result.Error
 	}
 
-	// Step 5: Publish Kafka event (TODO: implement Kafka producer)
-	// TODO: Publish reservation.cancelled event
-	// Event payload: {order_code, reservation_ids, ticket_class_summary, cancelled_at, total_count}
+	// Step 5: Record the reservation.cancelled event
+	if err := enqueueReservationsCancelled(tx, rs, CancelReasonReleased, time.Now().UTC()); err != nil {
+		s.l.Errorf(ctx, "service.reservation.CancelReservation.EnqueueEvent: %v", err)
+		return nil, err
+	}
 
 	s.l.Infof(ctx, "service.reservation.CancelReservation: successfully cancelled %d reservations for order_code=%s (total qty released: %d)",
 		len(rs), oCode, s.sumQuantities(tcUps))
-	return nil
+	return rs, nil
 }
 
-func (s implReservationService) BatchExpireReservations(ctx context.Context, batchSize int) (int, error) {
+func (s implReservationService) BatchExpireReservations(ctx context.Context, batchSize int) (_ int, err error) {
+	ctx, span := startSpan(ctx, "service.reservation.BatchExpireReservations")
+	defer func() { endSpan(span, err) }()
+
 	if batchSize <= 0 || batchSize > 1000 {
 		batchSize = 500 // Default batch size
 	}
 
 	totalExpired := 0
+	var tcIDs []int64
+	var expired []models.Reservation
+
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		totalExpired, tcIDs, expired = 0, nil, nil
 
-	return totalExpired, s.repo.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now().UTC()
-		s.l.Infof(ctx, "service.reservation.BatchExpireReservations: checking for expired reservations (now=%s, timezone=%s)",
+		s.l.Debugf(ctx, "service.reservation.BatchExpireReservations: checking for expired reservations (now=%s, timezone=%s)",
 			now.Format(time.RFC3339), now.Location())
 
 		var rs []models.Reservation
@@ -369,7 +650,7 @@ func (s implReservationService) BatchExpireReservations(ctx context.Context, bat
 			Strength: "UPDATE",
 			Options:  "SKIP LOCKED",
 		}).
-			Select("id", "ticket_class_id", "qty", "expires_at").
+			Select("id", "order_code", "ticket_class_id", "qty", "expires_at").
 			Where("status = ? AND expires_at < ?", models.ReservationStatusActive, now).
 			Order("expires_at").
 			Limit(batchSize).
@@ -381,7 +662,7 @@ func (s implReservationService) BatchExpireReservations(ctx context.Context, bat
 		}
 
 		if len(rs) == 0 {
-			s.l.Infof(ctx, "service.reservation.BatchExpireReservations: no expired reservations found")
+			s.l.Debug(ctx, "service.reservation.BatchExpireReservations: no expired reservations found")
 			return nil
 		}
 
@@ -396,7 +677,10 @@ func (s implReservationService) BatchExpireReservations(ctx context.Context, bat
 			rIDs = append(rIDs, r.ID)
 		}
 
-		for tcID, totalQty := range tsQtyMap {
+		// Update the counters in id order so concurrent multi-class transactions never deadlock
+		tcIDs = sortedTicketClassIDs(tsQtyMap)
+		for _, tcID := range tcIDs {
+			totalQty := tsQtyMap[tcID]
 			result := tx.Model(&models.TicketClass{}).
 				Where("id = ?", tcID).
 				Where("reserved >= ?", totalQty). // Safety check
@@ -427,22 +711,60 @@ func (s implReservationService) BatchExpireReservations(ctx context.Context, bat
 		}
 
 		totalExpired = len(rIDs)
+		expired = rs
 
-		// Step 5: Publish Kafka events (TODO: implement Kafka producer)
-		// TODO: Publish batch reservation.expired event
-		// Event payload: {reservation_ids, ticket_class_summary, expired_at, total_count}
+		// Step 5: Record one reservation.expired event per order
+		if err := enqueueReservationsExpired(tx, rs, now); err != nil {
+			s.l.Errorf(ctx, "service.reservation.BatchExpireReservations.EnqueueEvents: %v", err)
+			return err
+		}
 
 		s.l.Infof(ctx, "service.reservation.BatchExpireReservations: successfully expired %d reservations across %d ticket classes",
 			totalExpired, len(tsQtyMap))
 
 		return nil
 	})
+	if err != nil {
+		return 0, err
+	}
+
+	publishAvailability(ctx, s.bus, tcIDs...)
+	recordReservations(metrics.ReservationExpired, expired...)
+	recordExpiryLag(expired, time.Now().UTC())
+
+	return totalExpired, nil
+}
+
+// NextExpiry returns when the earliest active hold expires, nil when there are no active holds
+func (s implReservationService) NextExpiry(ctx context.Context) (_ *time.Time, err error) {
+	ctx, span := startSpan(ctx, "service.reservation.NextExpiry")
+	defer func() { endSpan(span, err) }()
+
+	// MIN over no rows is NULL
+	var next sql.NullTime
+	if err := s.repo.WithContext(ctx).
+		Model(&models.Reservation{}).
+		Select("MIN(expires_at)").
+		Where("status = ?", models.ReservationStatusActive).
+		Scan(&next).Error; err != nil {
+		s.l.Errorf(ctx, "service.reservation.NextExpiry: %v", err)
+		return nil, err
+	}
+
+	if !next.Valid {
+		return nil, nil
+	}
+
+	return &next.Time, nil
 }
 
-func (s implReservationService) Delete(ctx context.Context, id uint) error {
+func (s implReservationService) Delete(ctx context.Context, id uint) (err error) {
+	ctx, span := startSpan(ctx, "service.reservation.Delete")
+	defer func() { endSpan(span, err) }()
+
 	var r models.Reservation
 	if err := s.repo.FindByID(ctx, &r, id); err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.l.Warnf(ctx, "service.reservation.Delete: %v", err)
 		}
 		s.l.Errorf(ctx, "service.reservation.Delete: %v", err)
@@ -457,7 +779,10 @@ func (s implReservationService) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (s implReservationService) DeleteByOrderCode(ctx context.Context, oCode string) error {
+func (s implReservationService) DeleteByOrderCode(ctx context.Context, oCode string) (err error) {
+	ctx, span := startSpan(ctx, "service.reservation.DeleteByOrderCode", attrOrderCode.String(oCode))
+	defer func() { endSpan(span, err) }()
+
 	if err := s.repo.WithContext(ctx).Where("order_code = ?", oCode).Delete(&models.Reservation{}).Error; err != nil {
 		s.l.Errorf(ctx, "service.reservation.DeleteByOrderID: %v", err)
 		return err
@@ -466,7 +791,10 @@ func (s implReservationService) DeleteByOrderCode(ctx context.Context, oCode str
 	return nil
 }
 
-func (s implReservationService) GetTotalReservedQuantity(ctx context.Context, ticketClassID uint) (int, error) {
+func (s implReservationService) GetTotalReservedQuantity(ctx context.Context, ticketClassID uint) (_ int, err error) {
+	ctx, span := startSpan(ctx, "service.reservation.GetTotalReservedQuantity", attrTicketClassID.Int64(int64(ticketClassID)))
+	defer func() { endSpan(span, err) }()
+
 	var result struct {
 		Total int
 	}
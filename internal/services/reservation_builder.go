@@ -15,3 +15,19 @@ func (s implReservationService) buildModel(oCode string, expAt time.Time, in Res
 		ExpiresAt:     expAt,
 	}
 }
+
+func (s implReservationService) buildProcessedMessage(in ProcessOrderEventInput, outcome models.ProcessedMessageOutcome, err error) *models.ProcessedMessage {
+	pm := &models.ProcessedMessage{
+		MessageID:   in.MessageID,
+		Topic:       in.Topic,
+		EventType:   string(in.Type),
+		OrderCode:   in.OrderCode,
+		Outcome:     outcome,
+		ProcessedAt: time.Now().UTC(),
+	}
+	if err != nil {
+		pm.Error = err.Error()
+	}
+
+	return pm
+}
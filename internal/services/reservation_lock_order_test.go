@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vogiaan/ticketbottle-inventory/config"
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	"github.com/vogiaan/ticketbottle-inventory/internal/migrations"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+	"github.com/vogiaan/ticketbottle-inventory/internal/testdb"
+	"github.com/vogiaan/ticketbottle-inventory/internal/workers"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"github.com/vogiaan/ticketbottle-inventory/pkg/migrate"
+)
+
+// TestConfirmAndReleaseLockOrder confirms and releases orders over the same ticket classes at the same
+// time. Half of the orders took their holds from the highest class id down, so their reservations come
+// back in reverse class order. Counters must still be updated in class id order: with retries disabled,
+// any deadlock Postgres detects fails the test.
+func TestConfirmAndReleaseLockOrder(t *testing.T) {
+	const (
+		rounds = 20
+		orders = 8
+	)
+
+	ctx := context.Background()
+	repo := newMigratedRepository(t)
+	l := pkgLog.InitializeTestZapLogger()
+	bus := availability.NewLocalBus()
+	t.Cleanup(bus.Close)
+
+	rsvSvc := svc.NewReservationService(l, repo, bus, workers.NewExpirySignal())
+
+	ins := make([]svc.CreateTicketClassInput, 4)
+	for i := range ins {
+		ins[i] = svc.CreateTicketClassInput{EventID: "evt-lock-order", Name: fmt.Sprintf("class-%d", i), Currency: "USD", Total: 100_000}
+	}
+	tcs, err := svc.NewTicketClassService(l, repo, bus).BatchCreate(ctx, ins)
+	if err != nil {
+		t.Fatalf("BatchCreate(): %v", err)
+	}
+
+	tcIDs := make([]int64, len(tcs))
+	for i, tc := range tcs {
+		tcIDs[i] = tc.ID
+	}
+	slices.Sort(tcIDs)
+
+	sold := make(map[int64]int)
+	for round := range rounds {
+		oCodes := make([]string, orders)
+		for i := range oCodes {
+			oCodes[i] = fmt.Sprintf("order-%d-%d", round, i)
+
+			ids := slices.Clone(tcIDs)
+			if i%2 == 1 {
+				slices.Reverse(ids)
+			}
+			// One call per class, so the reservation ids follow the item order
+			for _, tcID := range ids {
+				if err := rsvSvc.Reserve(ctx, svc.ReserveInput{
+					OrderCode: oCodes[i],
+					Items:     []svc.ReserveItem{{TicketClassID: tcID, Qty: 1}},
+					ExpiresAt: time.Now().UTC().Add(time.Minute),
+				}); err != nil {
+					t.Fatalf("Reserve(%s, %d): %v", oCodes[i], tcID, err)
+				}
+			}
+		}
+
+		start := make(chan struct{})
+		errs := make([]error, orders)
+		var wg sync.WaitGroup
+		for i, oCode := range oCodes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				// Orders 0 and 1 are confirmed, 2 and 3 released and so on, so both directions meet both operations
+				if i%4 < 2 {
+					errs[i] = rsvSvc.Confirm(ctx, oCode)
+				} else {
+					errs[i] = rsvSvc.Release(ctx, oCode)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		for i, err := range errs {
+			if errors.Is(err, pkgGorm.ErrDeadlockDetected) {
+				t.Fatalf("round %d: %s deadlocked: %v", round, oCodes[i], err)
+			}
+			if err != nil {
+				t.Fatalf("round %d: %s: %v", round, oCodes[i], err)
+			}
+			if i%4 < 2 {
+				for _, tcID := range tcIDs {
+					sold[tcID]++
+				}
+			}
+		}
+	}
+
+	var got []models.TicketClass
+	if err := repo.WithContext(ctx).Where("id IN ?", tcIDs).Order("id").Find(&got).Error; err != nil {
+		t.Fatalf("failed to read ticket classes: %v", err)
+	}
+	for _, tc := range got {
+		if tc.Reserved != 0 || tc.Sold != sold[tc.ID] {
+			t.Errorf("ticket_class_id=%d: reserved=%d sold=%d, want reserved=0 sold=%d", tc.ID, tc.Reserved, tc.Sold, sold[tc.ID])
+		}
+	}
+}
+
+// newMigratedRepository migrates a throwaway schema and returns a repository that never retries transactions
+func newMigratedRepository(t *testing.T) *pkgGorm.Repository {
+	t.Helper()
+
+	db, err := pkgGorm.New(&config.PostgresConfig{
+		URL:           testdb.URL(t),
+		MaxOpenConns:  16,
+		MaxIdleConns:  16,
+		TxMaxAttempts: 1,
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		t.Fatalf("failed to get database instance: %v", err)
+	}
+
+	mgr, err := migrate.New(sqlDB, migrations.FS)
+	if err != nil {
+		t.Fatalf("migrate.New(): %v", err)
+	}
+	if _, err := mgr.Up(context.Background()); err != nil {
+		t.Fatalf("Up(): %v", err)
+	}
+
+	return pkgGorm.NewRepository(db)
+}
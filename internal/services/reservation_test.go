@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestNextExpiry(t *testing.T) {
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		min  any
+		want *time.Time
+	}{
+		// MIN over no active reservations is NULL
+		{name: "no active reservations", min: nil, want: nil},
+		{name: "earliest expiry", min: at, want: &at},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock := newMockReservationService(t)
+			mock.ExpectQuery(`SELECT MIN\(expires_at\) FROM "reservation"`).
+				WithArgs("ACTIVE").
+				WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow(tt.min))
+
+			got, err := s.NextExpiry(context.Background())
+			if err != nil {
+				t.Fatalf("NextExpiry() error = %v", err)
+			}
+
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("NextExpiry() = %v, want nil", *got)
+			case tt.want != nil && (got == nil || !got.Equal(*tt.want)):
+				t.Errorf("NextExpiry() = %v, want %v", got, *tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRecordFailedOrderEvent(t *testing.T) {
+	s, mock := newMockReservationService(t)
+	in := ProcessOrderEventInput{MessageID: "evt-1", Topic: "order.events", Type: OrderEventPaid, OrderCode: "order-1"}
+
+	mock.ExpectExec(`INSERT INTO "processed_message" .* ON CONFLICT DO NOTHING`).
+		WithArgs("order.events", "evt-1", "order.paid", "order-1", "FAILED", "counter drift", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := s.RecordFailedOrderEvent(context.Background(), in, errors.New("counter drift")); err != nil {
+		t.Fatalf("RecordFailedOrderEvent() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestCounterUpdatesInIDOrder checks that Confirm and Release update the ticket classes of an order in
+// ascending id order whatever order the holds were taken in, so two transactions never wait on each other
+// in a cycle. TestConfirmAndReleaseLockOrder runs the same scenario concurrently against Postgres.
+func TestCounterUpdatesInIDOrder(t *testing.T) {
+	errStop := errors.New("stop after the counters")
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name string
+		run  func(s implReservationService, tx *gorm.DB) error
+		// update is the counter statement, args builds its arguments from the class id and quantity
+		update string
+		args   func(tcID int64, qty int) []driver.Value
+	}{
+		{
+			name: "confirm",
+			run: func(s implReservationService, tx *gorm.DB) error {
+				_, err := s.confirmReservationTx(context.Background(), tx, "order-1")
+				return err
+			},
+			update: `UPDATE "ticket_class" SET "reserved"=reserved - \$1,"sold"=sold \+ \$2`,
+			args: func(tcID int64, qty int) []driver.Value {
+				return []driver.Value{int64(qty), int64(qty), sqlmock.AnyArg(), tcID, int64(qty)}
+			},
+		},
+		{
+			name: "release",
+			run: func(s implReservationService, tx *gorm.DB) error {
+				_, err := s.cancelReservationTx(context.Background(), tx, "order-1")
+				return err
+			},
+			update: `UPDATE "ticket_class" SET "reserved"=reserved - \$1,"updated_at"`,
+			args: func(tcID int64, qty int) []driver.Value {
+				return []driver.Value{int64(qty), sqlmock.AnyArg(), tcID, int64(qty)}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock := newMockReservationService(t)
+
+			// The holds were taken from the highest class id down, and class 30 twice
+			mock.ExpectQuery(`SELECT \* FROM "reservation" WHERE order_code = \$1 ORDER BY id FOR UPDATE`).
+				WithArgs("order-1").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "order_code", "ticket_class_id", "qty", "expires_at", "status"}).
+					AddRow(1, "order-1", 30, 3, expiresAt, "ACTIVE").
+					AddRow(2, "order-1", 20, 2, expiresAt, "ACTIVE").
+					AddRow(3, "order-1", 10, 1, expiresAt, "ACTIVE").
+					AddRow(4, "order-1", 30, 1, expiresAt, "ACTIVE"))
+
+			for _, up := range []struct {
+				tcID int64
+				qty  int
+			}{{10, 1}, {20, 2}, {30, 4}} {
+				mock.ExpectExec(tt.update).
+					WithArgs(tt.args(up.tcID, up.qty)...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectExec(`UPDATE "reservation" SET "status"`).WillReturnError(errStop)
+
+			if err := tt.run(s, s.repo.WithContext(context.Background())); !errors.Is(err, errStop) {
+				t.Fatalf("error = %v, want the error after the counter updates", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// newMockReservationService returns a service whose queries go to sqlmock, it cannot run transactions
+func newMockReservationService(t *testing.T) (implReservationService, sqlmock.Sqlmock) {
+	t.Helper()
+
+	repo, mock := newMockRepository(t)
+	return implReservationService{
+		l:    pkgLog.InitializeTestZapLogger(),
+		repo: repo,
+	}, mock
+}
+
+// newMockRepository returns a repository whose queries go to sqlmock, it cannot run transactions
+func newMockRepository(t *testing.T) (*pkgGorm.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New(): %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("gorm.Open(): %v", err)
+	}
+
+	return pkgGorm.NewRepository(&pkgGorm.DB{DB: db}), mock
+}
@@ -14,3 +14,18 @@ type ReserveItem struct {
 	TicketClassID int64
 	Qty           int
 }
+
+type OrderEventType string
+
+const (
+	OrderEventPaid      OrderEventType = "order.paid"
+	OrderEventCancelled OrderEventType = "order.cancelled"
+	OrderEventFailed    OrderEventType = "order.failed"
+)
+
+type ProcessOrderEventInput struct {
+	MessageID string
+	Topic     string
+	Type      OrderEventType
+	OrderCode string
+}
@@ -1,5 +1,10 @@
 package service
 
+import (
+	"github.com/vogiaan/ticketbottle-inventory/internal/domain"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+)
+
 func (s implReservationService) sumQuantities(m map[int64]int) int {
 	total := 0
 	for _, qty := range m {
@@ -7,3 +12,28 @@ func (s implReservationService) sumQuantities(m map[int64]int) int {
 	}
 	return total
 }
+
+// combineItems adds up the quantities of items for the same ticket class, keeping the first-seen order
+func (s implReservationService) combineItems(items []ReserveItem) []ReserveItem {
+	idx := make(map[int64]int, len(items))
+	combined := make([]ReserveItem, 0, len(items))
+
+	for _, item := range items {
+		if i, ok := idx[item.TicketClassID]; ok {
+			combined[i].Qty += item.Qty
+			continue
+		}
+		idx[item.TicketClassID] = len(combined)
+		combined = append(combined, item)
+	}
+
+	return combined
+}
+
+// notActiveError describes a reservation that can no longer be confirmed or cancelled
+func (s implReservationService) notActiveError(r models.Reservation) error {
+	return ErrReservationNotActive.
+		With(domain.MetaOrderCode, r.OrderCode).
+		WithInt(domain.MetaReservationID, r.ID).
+		With(domain.MetaStatus, string(r.Status))
+}
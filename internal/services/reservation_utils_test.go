@@ -0,0 +1,55 @@
+package service
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCombineItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []ReserveItem
+		want  []ReserveItem
+	}{
+		{
+			name:  "empty",
+			items: nil,
+			want:  []ReserveItem{},
+		},
+		{
+			name:  "distinct classes are kept as is",
+			items: []ReserveItem{{TicketClassID: 2, Qty: 1}, {TicketClassID: 1, Qty: 3}},
+			want:  []ReserveItem{{TicketClassID: 2, Qty: 1}, {TicketClassID: 1, Qty: 3}},
+		},
+		{
+			name:  "repeated class is summed",
+			items: []ReserveItem{{TicketClassID: 1, Qty: 1}, {TicketClassID: 1, Qty: 2}},
+			want:  []ReserveItem{{TicketClassID: 1, Qty: 3}},
+		},
+		{
+			name: "first-seen order is kept",
+			items: []ReserveItem{
+				{TicketClassID: 5, Qty: 1},
+				{TicketClassID: 3, Qty: 2},
+				{TicketClassID: 5, Qty: 4},
+				{TicketClassID: 9, Qty: 1},
+				{TicketClassID: 3, Qty: 1},
+			},
+			want: []ReserveItem{{TicketClassID: 5, Qty: 5}, {TicketClassID: 3, Qty: 3}, {TicketClassID: 9, Qty: 1}},
+		},
+	}
+
+	s := implReservationService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := slices.Clone(tt.items)
+
+			if got := s.combineItems(in); !slices.Equal(got, tt.want) {
+				t.Errorf("combineItems() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(in, tt.items) {
+				t.Errorf("combineItems() modified its input: %v, want %v", in, tt.items)
+			}
+		})
+	}
+}
@@ -2,92 +2,318 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	"github.com/vogiaan/ticketbottle-inventory/internal/domain"
+	"github.com/vogiaan/ticketbottle-inventory/internal/metrics"
 	"github.com/vogiaan/ticketbottle-inventory/internal/models"
 	pkgGorm "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
 	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TicketClassService interface {
 	Create(ctx context.Context, in CreateTicketClassInput) (models.TicketClass, error)
+	BatchCreate(ctx context.Context, ins []CreateTicketClassInput) ([]models.TicketClass, error)
+	CloneEvent(ctx context.Context, in CloneEventTicketClassesInput) ([]models.TicketClass, error)
 	Update(ctx context.Context, id int64, in UpdateTicketClassInput) (models.TicketClass, error)
 	GetByID(ctx context.Context, id int64) (models.TicketClass, error)
 	GetByEventID(ctx context.Context, eventID string) ([]models.TicketClass, error)
 	GetMany(ctx context.Context, in GetManyTicketClassInput) ([]models.TicketClass, error)
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id int64, in DeleteTicketClassInput) error
+	Restore(ctx context.Context, id int64) (models.TicketClass, error)
 	IncrementReserved(ctx context.Context, id int64, quantity int) error
 	DecrementReserved(ctx context.Context, id int64, quantity int) error
 	IncrementSold(ctx context.Context, id int64, quantity int) error
 	GetAvailableCount(ctx context.Context, id int64) (int, error)
-	CheckAvailability(ctx context.Context, ins []CheckAvailabilityInput) (bool, error)
+	CheckAvailability(ctx context.Context, ins []CheckAvailabilityInput) (CheckAvailabilityOutput, error)
 }
 
 type implTicketClassService struct {
 	l    pkgLog.Logger
 	repo *pkgGorm.Repository
+	bus  availability.Publisher
 }
 
-func NewTicketClassService(l pkgLog.Logger, repo *pkgGorm.Repository) TicketClassService {
+func NewTicketClassService(l pkgLog.Logger, repo *pkgGorm.Repository, bus availability.Publisher) TicketClassService {
 	return &implTicketClassService{
 		l:    l,
 		repo: repo,
+		bus:  bus,
 	}
 }
 
-func (s implTicketClassService) Create(ctx context.Context, in CreateTicketClassInput) (models.TicketClass, error) {
+func (s implTicketClassService) Create(ctx context.Context, in CreateTicketClassInput) (_ models.TicketClass, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.Create", attrEventID.String(in.EventID))
+	defer func() { endSpan(span, err) }()
+
 	tc := s.buildModel(in)
 	if err := s.repo.Create(ctx, &tc); err != nil {
 		s.l.Errorf(ctx, "service.ticketclass.Create: %v", err)
+		if errors.Is(err, pkgGorm.ErrUniqueViolation) {
+			return models.TicketClass{}, ErrTicketClassNameTaken.With(domain.MetaEventID, in.EventID)
+		}
+		return models.TicketClass{}, err
+	}
+
+	pauses, err := s.loadSalesPauses(ctx, tc)
+	if err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.Create.loadSalesPauses: %v", err)
 		return models.TicketClass{}, err
 	}
+	tc.SalesPause = pauses[tc.EventID]
 
 	return tc, nil
 }
 
-func (s implTicketClassService) Update(ctx context.Context, id int64, in UpdateTicketClassInput) (models.TicketClass, error) {
+func (s implTicketClassService) BatchCreate(ctx context.Context, ins []CreateTicketClassInput) (_ []models.TicketClass, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.BatchCreate")
+	defer func() { endSpan(span, err) }()
+
+	tcs := make([]models.TicketClass, len(ins))
+	for i, in := range ins {
+		tcs[i] = s.buildModel(in)
+	}
+
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		return s.createManyTx(ctx, tx, tcs)
+	})
+
+	if err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.BatchCreate: %v", err)
+		return nil, err
+	}
+
+	if err := s.attachSalesPauses(ctx, tcs); err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.BatchCreate.attachSalesPauses: %v", err)
+		return nil, err
+	}
+
+	return tcs, nil
+}
+
+func (s implTicketClassService) CloneEvent(ctx context.Context, in CloneEventTicketClassesInput) (_ []models.TicketClass, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.CloneEvent")
+	defer func() { endSpan(span, err) }()
+
+	var srcs []models.TicketClass
+	if err := s.repo.WithContext(ctx).
+		Where("event_id = ?", in.SourceEventID).
+		Order("id").
+		Find(&srcs).Error; err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.CloneEvent.FindSource: %v", err)
+		return nil, err
+	}
+
+	if len(srcs) == 0 {
+		s.l.Warnf(ctx, "service.ticketclass.CloneEvent: no ticket classes found for event_id=%s", in.SourceEventID)
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	tcs := make([]models.TicketClass, len(srcs))
+	for i, src := range srcs {
+		tcs[i] = s.buildClone(src, in.TargetEventID, in.TimeShift)
+	}
+
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		return s.createManyTx(ctx, tx, tcs)
+	})
+
+	if err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.CloneEvent: %v", err)
+		return nil, err
+	}
+
+	s.l.Infof(ctx, "service.ticketclass.CloneEvent: cloned %d ticket classes from event_id=%s to event_id=%s",
+		len(tcs), in.SourceEventID, in.TargetEventID)
+
+	if err := s.attachSalesPauses(ctx, tcs); err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.CloneEvent.attachSalesPauses: %v", err)
+		return nil, err
+	}
+
+	return tcs, nil
+}
+
+// createManyTx inserts all ticket classes or none of them.
+func (s implTicketClassService) createManyTx(ctx context.Context, tx *gorm.DB, tcs []models.TicketClass) error {
+	eventIDs := make([]string, 0, len(tcs))
+	names := make([]string, 0, len(tcs))
+	for _, tc := range tcs {
+		eventIDs = append(eventIDs, tc.EventID)
+		names = append(names, tc.Name)
+	}
+
+	// Step 1: Check names against live classes so a conflict is reported before anything is written
+	var existing []models.TicketClass
+	if err := tx.Select("event_id", "name").
+		Where("event_id IN ? AND name IN ?", eventIDs, names).
+		Find(&existing).Error; err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.createManyTx.FindExisting: %v", err)
+		return err
+	}
+
+	taken := make(map[string]bool, len(existing))
+	for _, tc := range existing {
+		taken[tc.EventID+"/"+tc.Name] = true
+	}
+
+	for _, tc := range tcs {
+		if taken[tc.EventID+"/"+tc.Name] {
+			s.l.Warnf(ctx, "service.ticketclass.createManyTx: name %q is already used in event %s", tc.Name, tc.EventID)
+			return ErrTicketClassNameTaken.With(domain.MetaEventID, tc.EventID)
+		}
+	}
+
+	// Step 2: Insert all rows in one statement, a retried transaction must not reuse ids of the rolled back insert
+	for i := range tcs {
+		tcs[i].ID = 0
+	}
+
+	if err := tx.Create(&tcs).Error; err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.createManyTx.Insert: %v", err)
+		if errors.Is(err, pkgGorm.ErrUniqueViolation) {
+			// A concurrent request took one of the names after the check above
+			return ErrTicketClassNameTaken
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s implTicketClassService) Update(ctx context.Context, id int64, in UpdateTicketClassInput) (_ models.TicketClass, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.Update", attrTicketClassID.Int64(id))
+	defer func() { endSpan(span, err) }()
+
 	var tc models.TicketClass
-	if err := s.repo.FindByID(ctx, &tc, id); err != nil {
-		if err == gorm.ErrRecordNotFound {
-			s.l.Warnf(ctx, "service.ticketclass.Update: %v", err)
+
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		// Step 1: Lock the ticket class row, same as Reserve does, so counters cannot move underneath us
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&tc, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				s.l.Warnf(ctx, "service.ticketclass.Update: %v", err)
+			}
+			s.l.Errorf(ctx, "service.ticketclass.Update.LockTicketClass: %v", err)
+			return err
+		}
+
+		// Step 2: Classes frozen by EventService.Cancel stay frozen, an update must not put them back on sale
+		if tc.Status == models.TicketClassStatusCancelled {
+			s.l.Warnf(ctx, "service.ticketclass.Update: ticket_class_id=%d is cancelled", tc.ID)
+			return ErrTicketClassCancelled.WithInt(domain.MetaTicketClassID, tc.ID)
+		}
+
+		// Step 3: Reject stale writes when the caller sent the version it read
+		if in.ExpectedVersion != 0 && in.ExpectedVersion != tc.Version {
+			s.l.Warnf(ctx, "service.ticketclass.Update: version mismatch for ticket_class_id=%d (expected=%d, current=%d)",
+				tc.ID, in.ExpectedVersion, tc.Version)
+			return ErrVersionMismatch.WithInt(domain.MetaTicketClassID, tc.ID).WithInt(domain.MetaCurrentVersion, tc.Version)
+		}
+
+		// Step 4: Make sure the new capacity still covers reserved and sold tickets
+		oversold, err := s.checkCapacity(tc, in.Total, in.Force)
+		if err != nil {
+			s.l.Warnf(ctx, "service.ticketclass.Update: ticket_class_id=%d total=%d is below committed stock (reserved=%d, sold=%d)",
+				tc.ID, in.Total, tc.Reserved, tc.Sold)
+			return err
+		}
+
+		if oversold > 0 {
+			s.l.Warnf(ctx, "service.ticketclass.Update: forced capacity change leaves ticket_class_id=%d oversold by %d",
+				tc.ID, oversold)
 		}
-		s.l.Errorf(ctx, "service.ticketclass.Update: %v", err)
+
+		// Step 5: Apply the changes and bump the version
+		prevTotal := tc.Total
+		s.buildUpdate(in, &tc)
+		tc.Oversold = oversold
+		tc.Version++
+
+		if err := tx.Save(&tc).Error; err != nil {
+			s.l.Errorf(ctx, "service.ticketclass.Update.Save: %v", err)
+			if errors.Is(err, pkgGorm.ErrUniqueViolation) {
+				return ErrTicketClassNameTaken.With(domain.MetaEventID, tc.EventID)
+			}
+			return err
+		}
+
+		// Step 6: Record the capacity change for downstream consumers
+		if tc.Total != prevTotal {
+			if err := enqueueCapacityChanged(tx, tc, prevTotal, time.Now().UTC()); err != nil {
+				s.l.Errorf(ctx, "service.ticketclass.Update.EnqueueEvent: %v", err)
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
 		return models.TicketClass{}, err
 	}
 
-	s.buildUpdate(in, &tc)
-	if err := s.repo.Update(ctx, &tc); err != nil {
-		s.l.Errorf(ctx, "service.ticketclass.Update: %v", err)
+	publishAvailability(ctx, s.bus, tc.ID)
+
+	pauses, err := s.loadSalesPauses(ctx, tc)
+	if err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.Update.loadSalesPauses: %v", err)
 		return models.TicketClass{}, err
 	}
+	tc.SalesPause = pauses[tc.EventID]
 
 	return tc, nil
 }
 
-func (s implTicketClassService) GetByID(ctx context.Context, id int64) (models.TicketClass, error) {
+func (s implTicketClassService) GetByID(ctx context.Context, id int64) (_ models.TicketClass, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.GetByID", attrTicketClassID.Int64(id))
+	defer func() { endSpan(span, err) }()
+
 	var tc models.TicketClass
 	if err := s.repo.FindByID(ctx, &tc, id); err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.l.Warnf(ctx, "service.ticketclass.GetByID: %v", err)
 		}
 		s.l.Errorf(ctx, "service.ticketclass.GetByID: %v", err)
 		return models.TicketClass{}, err
 	}
 
+	pauses, err := s.loadSalesPauses(ctx, tc)
+	if err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.GetByID.loadSalesPauses: %v", err)
+		return models.TicketClass{}, err
+	}
+	tc.SalesPause = pauses[tc.EventID]
+
 	return tc, nil
 }
 
-func (s implTicketClassService) GetByEventID(ctx context.Context, eventID string) ([]models.TicketClass, error) {
+func (s implTicketClassService) GetByEventID(ctx context.Context, eventID string) (_ []models.TicketClass, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.GetByEventID", attrEventID.String(eventID))
+	defer func() { endSpan(span, err) }()
+
 	var tcs []models.TicketClass
 	if err := s.repo.FindWhere(ctx, &tcs, "event_id = ?", eventID); err != nil {
 		s.l.Errorf(ctx, "service.ticketclass.GetByEventID: %v", err)
 		return nil, err
 	}
 
+	if err := s.attachSalesPauses(ctx, tcs); err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.GetByEventID.attachSalesPauses: %v", err)
+		return nil, err
+	}
+
 	return tcs, nil
 }
 
-func (s implTicketClassService) GetMany(ctx context.Context, in GetManyTicketClassInput) ([]models.TicketClass, error) {
+func (s implTicketClassService) GetMany(ctx context.Context, in GetManyTicketClassInput) (_ []models.TicketClass, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.GetMany")
+	defer func() { endSpan(span, err) }()
+
 	var tcs []models.TicketClass
 
 	// Build dynamic query
@@ -109,78 +335,262 @@ func (s implTicketClassService) GetMany(ctx context.Context, in GetManyTicketCla
 		return nil, err
 	}
 
+	if err := s.attachSalesPauses(ctx, tcs); err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.GetMany.attachSalesPauses: %v", err)
+		return nil, err
+	}
+
 	return tcs, nil
 }
 
-func (s *implTicketClassService) Delete(ctx context.Context, id int64) error {
+func (s *implTicketClassService) Delete(ctx context.Context, id int64, in DeleteTicketClassInput) (err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.Delete", attrTicketClassID.Int64(id))
+	defer func() { endSpan(span, err) }()
+
+	var cancelled []models.Reservation
+
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		cancelled = nil
+
+		// Step 1: Lock the ticket class row so no hold can be taken while we inspect it
+		var tc models.TicketClass
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&tc, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				s.l.Warnf(ctx, "service.ticketclass.Delete: %v", err)
+				return err
+			}
+			s.l.Errorf(ctx, "service.ticketclass.Delete.LockTicketClass: %v", err)
+			return err
+		}
+
+		// Step 2: Refuse to delete classes that still have holds or sales unless forced
+		var rs []models.Reservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("ticket_class_id = ? AND status = ?", tc.ID, models.ReservationStatusActive).
+			Order("id").
+			Find(&rs).Error; err != nil {
+			s.l.Errorf(ctx, "service.ticketclass.Delete.LockReservations: %v", err)
+			return err
+		}
+
+		if (len(rs) > 0 || tc.Sold > 0) && !in.Force {
+			s.l.Warnf(ctx, "service.ticketclass.Delete: ticket_class_id=%d is in use (active_holds=%d, sold=%d)",
+				tc.ID, len(rs), tc.Sold)
+			return ErrTicketClassInUse.WithInt(domain.MetaTicketClassID, tc.ID)
+		}
+
+		// Step 3: Cancel remaining holds so the reserved counter does not point at a deleted class
+		if len(rs) > 0 {
+			qty := 0
+			rIDs := make([]int64, 0, len(rs))
+			for _, r := range rs {
+				qty += r.Qty
+				rIDs = append(rIDs, r.ID)
+			}
+
+			result := tx.Model(&models.TicketClass{}).
+				Where("id = ?", tc.ID).
+				Where("reserved >= ?", qty). // Safety check
+				Update("reserved", gorm.Expr("reserved - ?", qty))
+
+			if result.Error != nil {
+				s.l.Errorf(ctx, "service.ticketclass.Delete.DecrementReserved: %v", result.Error)
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				s.l.Errorf(ctx, "service.ticketclass.Delete: insufficient reserved tickets for ticket_class_id=%d (needed=%d)", tc.ID, qty)
+				return ErrCounterMismatch.WithInt(domain.MetaTicketClassID, tc.ID)
+			}
+
+			if err := tx.Model(&models.Reservation{}).
+				Where("id IN ?", rIDs).
+				Update("status", models.ReservationStatusCancelled).Error; err != nil {
+				s.l.Errorf(ctx, "service.ticketclass.Delete.CancelReservations: %v", err)
+				return err
+			}
+
+			if err := enqueueReservationsCancelled(tx, rs, CancelReasonTicketClassDeleted, time.Now().UTC()); err != nil {
+				s.l.Errorf(ctx, "service.ticketclass.Delete.EnqueueEvents: %v", err)
+				return err
+			}
+
+			s.l.Warnf(ctx, "service.ticketclass.Delete: force cancelled %d active holds (qty=%d) for ticket_class_id=%d",
+				len(rs), qty, tc.ID)
+			cancelled = rs
+		}
+
+		// Step 4: Soft delete the ticket class
+		if err := tx.Delete(&tc).Error; err != nil {
+			s.l.Errorf(ctx, "service.ticketclass.Delete: %v", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The class is no longer returned by GetMany, watchers get a DELETED update for it
+	publishAvailability(ctx, s.bus, id)
+	recordReservations(metrics.ReservationCancelled, cancelled...)
+	return nil
+}
+
+func (s *implTicketClassService) Restore(ctx context.Context, id int64) (_ models.TicketClass, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.Restore", attrTicketClassID.Int64(id))
+	defer func() { endSpan(span, err) }()
+
 	var tc models.TicketClass
-	if err := s.repo.FindByID(ctx, &tc, id); err != nil {
-		if err == gorm.ErrRecordNotFound {
-			s.l.Warnf(ctx, "service.ticketclass.Delete: %v", err)
+
+	err = s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&tc, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				s.l.Warnf(ctx, "service.ticketclass.Restore: %v", err)
+			}
+			s.l.Errorf(ctx, "service.ticketclass.Restore.LockTicketClass: %v", err)
+			return err
+		}
+
+		if !tc.DeletedAt.Valid {
 			return nil
 		}
-		s.l.Errorf(ctx, "service.ticketclass.Delete: %v", err)
-		return err
+
+		// A new class may have taken the name while this one was deleted
+		var cnt int64
+		if err := tx.Model(&models.TicketClass{}).
+			Where("event_id = ? AND name = ?", tc.EventID, tc.Name).
+			Count(&cnt).Error; err != nil {
+			s.l.Errorf(ctx, "service.ticketclass.Restore.CountByName: %v", err)
+			return err
+		}
+
+		if cnt > 0 {
+			s.l.Warnf(ctx, "service.ticketclass.Restore: name %q is already used in event %s", tc.Name, tc.EventID)
+			return ErrTicketClassNameTaken.With(domain.MetaEventID, tc.EventID)
+		}
+
+		if err := tx.Unscoped().Model(&tc).Update("deleted_at", nil).Error; err != nil {
+			s.l.Errorf(ctx, "service.ticketclass.Restore: %v", err)
+			return err
+		}
+
+		// Return the row as stored, the update does not clear DeletedAt on tc
+		tc = models.TicketClass{}
+		if err := tx.First(&tc, id).Error; err != nil {
+			s.l.Errorf(ctx, "service.ticketclass.Restore.ReloadTicketClass: %v", err)
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return models.TicketClass{}, err
 	}
 
-	if err := s.repo.Delete(ctx, &tc); err != nil {
-		s.l.Errorf(ctx, "service.ticketclass.Delete: %v", err)
-		return err
+	// Watchers that got the DELETED update see the class again
+	publishAvailability(ctx, s.bus, id)
+
+	pauses, err := s.loadSalesPauses(ctx, tc)
+	if err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.Restore.loadSalesPauses: %v", err)
+		return models.TicketClass{}, err
 	}
+	tc.SalesPause = pauses[tc.EventID]
 
-	return nil
+	return tc, nil
 }
 
-func (s *implTicketClassService) IncrementReserved(ctx context.Context, id int64, quantity int) error {
-	return s.repo.WithContext(ctx).
+func (s *implTicketClassService) IncrementReserved(ctx context.Context, id int64, quantity int) (err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.IncrementReserved", attrTicketClassID.Int64(id))
+	defer func() { endSpan(span, err) }()
+
+	if err := s.repo.WithContext(ctx).
 		Model(&models.TicketClass{}).
 		Where("id = ?", id).
 		Where("total >= reserved + sold + ?", quantity). // Check availability
-		Update("reserved", gorm.Expr("reserved + ?", quantity)).Error
+		Update("reserved", gorm.Expr("reserved + ?", quantity)).Error; err != nil {
+		return err
+	}
+
+	publishAvailability(ctx, s.bus, id)
+	return nil
 }
 
-func (s *implTicketClassService) DecrementReserved(ctx context.Context, id int64, quantity int) error {
-	return s.repo.WithContext(ctx).
+func (s *implTicketClassService) DecrementReserved(ctx context.Context, id int64, quantity int) (err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.DecrementReserved", attrTicketClassID.Int64(id))
+	defer func() { endSpan(span, err) }()
+
+	if err := s.repo.WithContext(ctx).
 		Model(&models.TicketClass{}).
 		Where("id = ?", id).
-		Update("reserved", gorm.Expr("GREATEST(0, reserved - ?)", quantity)).Error
+		Update("reserved", gorm.Expr("reserved - ?", quantity)).Error; err != nil {
+		return err
+	}
+
+	publishAvailability(ctx, s.bus, id)
+	return nil
 }
 
-func (s *implTicketClassService) IncrementSold(ctx context.Context, id int64, quantity int) error {
-	return s.repo.WithContext(ctx).
+func (s *implTicketClassService) IncrementSold(ctx context.Context, id int64, quantity int) (err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.IncrementSold", attrTicketClassID.Int64(id))
+	defer func() { endSpan(span, err) }()
+
+	if err := s.repo.WithContext(ctx).
 		Model(&models.TicketClass{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
 			"sold":     gorm.Expr("sold + ?", quantity),
-			"reserved": gorm.Expr("GREATEST(0, reserved - ?)", quantity),
-		}).Error
+			"reserved": gorm.Expr("reserved - ?", quantity),
+		}).Error; err != nil {
+		return err
+	}
+
+	publishAvailability(ctx, s.bus, id)
+	return nil
 }
 
-func (s *implTicketClassService) GetAvailableCount(ctx context.Context, id int64) (int, error) {
+func (s *implTicketClassService) GetAvailableCount(ctx context.Context, id int64) (_ int, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.GetAvailableCount", attrTicketClassID.Int64(id))
+	defer func() { endSpan(span, err) }()
+
 	var tc models.TicketClass
 	if err := s.repo.FindByID(ctx, &tc, id); err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.l.Warnf(ctx, "service.ticketclass.GetAvailableCount: %v", err)
 		}
 		s.l.Errorf(ctx, "service.ticketclass.GetAvailableCount: %v", err)
 		return 0, err
 	}
 
-	return tc.Total - tc.Reserved - tc.Sold, nil
+	return tc.AvailableQty(), nil
 }
 
-func (s *implTicketClassService) CheckAvailability(ctx context.Context, ins []CheckAvailabilityInput) (bool, error) {
+// CheckAvailability reports every requested ticket class, quantities of duplicate ids are added up.
+// Accept is true only when every item can be held.
+func (s *implTicketClassService) CheckAvailability(ctx context.Context, ins []CheckAvailabilityInput) (_ CheckAvailabilityOutput, err error) {
+	ctx, span := startSpan(ctx, "service.ticketclass.CheckAvailability")
+	defer func() { endSpan(span, err) }()
+
+	out := CheckAvailabilityOutput{Accept: true}
 	if len(ins) == 0 {
-		return true, nil
+		return out, nil
 	}
 
-	// Extract all ticket class IDs
+	// Combine duplicate ids, keeping the order of first appearance
 	ids := make([]int64, 0, len(ins))
 	qtyMap := make(map[int64]int)
 
 	for _, in := range ins {
-		ids = append(ids, in.TicketClassID)
-		qtyMap[in.TicketClassID] = in.Qty
+		if _, ok := qtyMap[in.TicketClassID]; !ok {
+			ids = append(ids, in.TicketClassID)
+		}
+		qtyMap[in.TicketClassID] += in.Qty
 	}
 
 	// Fetch all ticket classes at once
@@ -190,26 +600,45 @@ func (s *implTicketClassService) CheckAvailability(ctx context.Context, ins []Ch
 		Where("id IN ?", ids).
 		Find(&ticketClasses).Error; err != nil {
 		s.l.Errorf(ctx, "service.ticketclass.CheckAvailability: %v", err)
-		return false, err
+		return CheckAvailabilityOutput{}, err
 	}
 
-	// Check if we found all requested ticket classes
-	if len(ticketClasses) != len(ins) {
-		s.l.Warnf(ctx, "service.ticketclass.CheckAvailability: requested %d ticket classes, found %d", len(ins), len(ticketClasses))
-		return false, nil
+	pauses, err := s.loadSalesPauses(ctx, ticketClasses...)
+	if err != nil {
+		s.l.Errorf(ctx, "service.ticketclass.CheckAvailability.loadSalesPauses: %v", err)
+		return CheckAvailabilityOutput{}, err
 	}
 
-	// Check availability for each ticket class
+	tcMap := make(map[int64]models.TicketClass, len(ticketClasses))
 	for _, tc := range ticketClasses {
-		requestedQty := qtyMap[tc.ID]
-		availableQty := tc.Total - tc.Reserved - tc.Sold
+		tcMap[tc.ID] = tc
+	}
 
-		if availableQty < requestedQty {
-			s.l.Warnf(ctx, "service.ticketclass.CheckAvailability: insufficient stock for ticket_class_id=%d (available=%d, requested=%d)",
-				tc.ID, availableQty, requestedQty)
-			return false, nil
+	// Check availability for each ticket class
+	now := time.Now().UTC()
+	out.Items = make([]CheckAvailabilityItemOutput, 0, len(ids))
+
+	for _, id := range ids {
+		item := CheckAvailabilityItemOutput{
+			TicketClassID: id,
+			RequestedQty:  qtyMap[id],
+			Reason:        AvailabilityReasonNotFound,
 		}
+
+		if tc, ok := tcMap[id]; ok {
+			item.AvailableQty = tc.AvailableQty()
+			item.Reason = checkSellable(tc, pauses[tc.EventID] != nil, item.RequestedQty, now)
+		}
+
+		item.Accepted = item.Reason == AvailabilityReasonOK
+		if !item.Accepted {
+			s.l.Warnf(ctx, "service.ticketclass.CheckAvailability: ticket_class_id=%d rejected (reason=%s, available=%d, requested=%d)",
+				id, item.Reason, item.AvailableQty, item.RequestedQty)
+			out.Accept = false
+		}
+
+		out.Items = append(out.Items, item)
 	}
 
-	return true, nil
+	return out, nil
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"time"
+
 	"github.com/vogiaan/ticketbottle-inventory/internal/models"
 )
 
@@ -15,6 +17,7 @@ func (s implTicketClassService) buildUpdate(in UpdateTicketClassInput, model *mo
 	model.Total = in.Total
 	model.SaleStartAt = in.SaleStartAt
 	model.SaleEndAt = in.SaleEndAt
+	model.MaxPerOrder = in.MaxPerOrder
 	if in.Status != nil {
 		model.Status = models.TicketClassStatus(*in.Status)
 	}
@@ -29,6 +32,32 @@ func (s implTicketClassService) buildModel(in CreateTicketClassInput) models.Tic
 		Total:       in.Total,
 		SaleStartAt: in.SaleStartAt,
 		SaleEndAt:   in.SaleEndAt,
+		MaxPerOrder: in.MaxPerOrder,
 		Status:      models.TicketClassStatusActive,
+		Version:     1,
+	}
+}
+
+// buildClone copies the definition of src into eventID with fresh counters.
+func (s implTicketClassService) buildClone(src models.TicketClass, eventID string, shift time.Duration) models.TicketClass {
+	return models.TicketClass{
+		EventID:     eventID,
+		Name:        src.Name,
+		PriceCents:  src.PriceCents,
+		Currency:    src.Currency,
+		Total:       src.Total,
+		SaleStartAt: shiftTime(src.SaleStartAt, shift),
+		SaleEndAt:   shiftTime(src.SaleEndAt, shift),
+		MaxPerOrder: src.MaxPerOrder,
+		Status:      models.TicketClassStatusActive,
+		Version:     1,
+	}
+}
+
+func shiftTime(t *time.Time, shift time.Duration) *time.Time {
+	if t == nil {
+		return nil
 	}
+	shifted := t.Add(shift)
+	return &shifted
 }
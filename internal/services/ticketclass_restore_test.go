@@ -0,0 +1,36 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/availability"
+	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+func TestRestoreReturnsRestoredClass(t *testing.T) {
+	ctx := context.Background()
+	repo := newMigratedRepository(t)
+	bus := availability.NewLocalBus()
+	t.Cleanup(bus.Close)
+
+	tcSvc := svc.NewTicketClassService(pkgLog.InitializeTestZapLogger(), repo, bus)
+	tcs, err := tcSvc.BatchCreate(ctx, []svc.CreateTicketClassInput{{EventID: "evt-restore", Name: "General", Currency: "USD", Total: 10}})
+	if err != nil {
+		t.Fatalf("BatchCreate(): %v", err)
+	}
+	id := tcs[0].ID
+
+	if err := tcSvc.Delete(ctx, id, svc.DeleteTicketClassInput{}); err != nil {
+		t.Fatalf("Delete(): %v", err)
+	}
+
+	tc, err := tcSvc.Restore(ctx, id)
+	if err != nil {
+		t.Fatalf("Restore(): %v", err)
+	}
+	if tc.ID != id || tc.DeletedAt.Valid {
+		t.Errorf("Restore() = id %d, deleted_at %v, want id %d and no deleted_at", tc.ID, tc.DeletedAt, id)
+	}
+}
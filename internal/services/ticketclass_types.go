@@ -12,16 +12,30 @@ type CreateTicketClassInput struct {
 	Total       int
 	SaleStartAt *time.Time
 	SaleEndAt   *time.Time
+	MaxPerOrder int
 }
 
 type UpdateTicketClassInput struct {
-	Name        string
-	PriceCents  *int64
-	Currency    string
-	Total       int
-	SaleStartAt *time.Time
-	SaleEndAt   *time.Time
-	Status      *string
+	Name            string
+	PriceCents      *int64
+	Currency        string
+	Total           int
+	SaleStartAt     *time.Time
+	SaleEndAt       *time.Time
+	Status          *string
+	MaxPerOrder     int
+	Force           bool
+	ExpectedVersion int64
+}
+
+type CloneEventTicketClassesInput struct {
+	SourceEventID string
+	TargetEventID string
+	TimeShift     time.Duration
+}
+
+type DeleteTicketClassInput struct {
+	Force bool
 }
 
 type CheckAvailabilityInput struct {
@@ -29,6 +43,19 @@ type CheckAvailabilityInput struct {
 	Qty           int
 }
 
+type CheckAvailabilityOutput struct {
+	Accept bool
+	Items  []CheckAvailabilityItemOutput
+}
+
+type CheckAvailabilityItemOutput struct {
+	TicketClassID int64
+	RequestedQty  int
+	AvailableQty  int
+	Accepted      bool
+	Reason        AvailabilityReason
+}
+
 type GetManyTicketClassInput struct {
 	EventID string
 	IDs     []int64
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vogiaan/ticketbottle-inventory/internal/domain"
+	"github.com/vogiaan/ticketbottle-inventory/internal/models"
+)
+
+// checkCapacity verifies that total still covers the reserved and sold tickets of tc.
+// In force mode the shortfall is returned as the oversold amount instead of an error.
+func (s implTicketClassService) checkCapacity(tc models.TicketClass, total int, force bool) (int, error) {
+	committed := tc.Reserved + tc.Sold
+	if total >= committed {
+		return 0, nil
+	}
+
+	if !force {
+		return 0, ErrCapacityBelowCommitted.WithInt(domain.MetaTicketClassID, tc.ID)
+	}
+
+	return committed - total, nil
+}
+
+// loadSalesPauses returns the active sales pauses of the given events keyed by event ID.
+func (s implTicketClassService) loadSalesPauses(ctx context.Context, tcs ...models.TicketClass) (map[string]*models.EventSalesPause, error) {
+	pauses := make(map[string]*models.EventSalesPause)
+	if len(tcs) == 0 {
+		return pauses, nil
+	}
+
+	eventIDs := make([]string, 0, len(tcs))
+	for _, tc := range tcs {
+		eventIDs = append(eventIDs, tc.EventID)
+	}
+
+	var ps []models.EventSalesPause
+	if err := s.repo.WithContext(ctx).
+		Where("event_id IN ?", eventIDs).
+		Find(&ps).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range ps {
+		pauses[ps[i].EventID] = &ps[i]
+	}
+
+	return pauses, nil
+}
+
+// attachSalesPauses fills SalesPause on every ticket class in place.
+func (s implTicketClassService) attachSalesPauses(ctx context.Context, tcs []models.TicketClass) error {
+	pauses, err := s.loadSalesPauses(ctx, tcs...)
+	if err != nil {
+		return err
+	}
+
+	for i := range tcs {
+		tcs[i].SalesPause = pauses[tcs[i].EventID]
+	}
+
+	return nil
+}
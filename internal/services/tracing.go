@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	attrOrderCode     = attribute.Key("inventory.order_code")
+	attrTicketClassID = attribute.Key("inventory.ticket_class_id")
+	attrEventID       = attribute.Key("inventory.event_id")
+	attrOrderEvent    = attribute.Key("inventory.order_event")
+)
+
+var tracer = otel.Tracer("github.com/vogiaan/ticketbottle-inventory/internal/services")
+
+// startSpan starts a span named like the log prefix of the method, e.g. service.reservation.Reserve
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err on the span and ends it, defer it with the named error result
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,76 @@
+// Package testdb gives integration tests a throwaway Postgres schema.
+// Tests using it are skipped unless INVENTORY_TEST_DATABASE_URL is set.
+package testdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// EnvDSN names the variable holding the connection string of the test database
+const EnvDSN = "INVENTORY_TEST_DATABASE_URL"
+
+// Open returns a connection pool whose search_path is a new empty schema, dropped when the test ends.
+// Tables created by the test land in that schema, so the database itself is left untouched.
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("pgx", URL(t))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// URL is Open for code that connects by itself, it returns a connection string using the new schema
+func URL(t testing.TB) string {
+	t.Helper()
+
+	dsn := os.Getenv(EnvDSN)
+	if dsn == "" {
+		t.Skipf("%s is not set", EnvDSN)
+	}
+
+	ctx := context.Background()
+	schema := fmt.Sprintf("inventory_test_%d", time.Now().UnixNano())
+
+	admin, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	if _, err := admin.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("failed to create schema %s: %v", schema, err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("failed to drop schema %s: %v", schema, err)
+		}
+	})
+
+	// Both connection string forms pass unknown settings on as runtime parameters
+	if !strings.Contains(dsn, "://") {
+		return dsn + " search_path=" + schema
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", EnvDSN, err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
@@ -0,0 +1,50 @@
+package workers
+
+import (
+	"sync"
+	"time"
+)
+
+// ExpirySignal carries the expiry of new holds from the reservation service to the expiry worker
+// of the same process, PgExpirySignal feeds it with the holds of other replicas.
+// Notifications are coalesced, the worker only sees the earliest one since it last looked.
+type ExpirySignal struct {
+	mu       sync.Mutex
+	earliest time.Time
+	notifyCh chan struct{}
+}
+
+func NewExpirySignal() *ExpirySignal {
+	return &ExpirySignal{
+		notifyCh: make(chan struct{}, 1),
+	}
+}
+
+// NotifyExpiry records at and wakes the worker, it never blocks
+func (s *ExpirySignal) NotifyExpiry(at time.Time) {
+	s.mu.Lock()
+	if s.earliest.IsZero() || at.Before(s.earliest) {
+		s.earliest = at
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// C is signalled after NotifyExpiry, call take to read the expiry
+func (s *ExpirySignal) C() <-chan struct{} {
+	return s.notifyCh
+}
+
+// take returns the earliest notified expiry and resets it
+func (s *ExpirySignal) take() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at := s.earliest
+	s.earliest = time.Time{}
+	return at
+}
@@ -0,0 +1,70 @@
+package workers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpirySignalTake(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		notify  []time.Time
+		want    time.Time
+		wantSig bool
+	}{
+		{name: "nothing notified", notify: nil, want: time.Time{}, wantSig: false},
+		{name: "single", notify: []time.Time{base}, want: base, wantSig: true},
+		{name: "earliest wins", notify: []time.Time{base.Add(time.Minute), base, base.Add(time.Hour)}, want: base, wantSig: true},
+		{name: "later notifications do not move it back", notify: []time.Time{base, base.Add(time.Second)}, want: base, wantSig: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewExpirySignal()
+			for _, at := range tt.notify {
+				s.NotifyExpiry(at)
+			}
+
+			select {
+			case <-s.C():
+				if !tt.wantSig {
+					t.Error("C() was signalled without a notification")
+				}
+			default:
+				if tt.wantSig {
+					t.Error("C() was not signalled")
+				}
+			}
+
+			// Notifications are coalesced into a single wake up
+			select {
+			case <-s.C():
+				t.Error("C() was signalled more than once")
+			default:
+			}
+
+			if got := s.take(); !got.Equal(tt.want) {
+				t.Errorf("take() = %v, want %v", got, tt.want)
+			}
+			if got := s.take(); !got.IsZero() {
+				t.Errorf("second take() = %v, want zero", got)
+			}
+		})
+	}
+}
+
+func TestExpirySignalAfterTake(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	s := NewExpirySignal()
+	s.NotifyExpiry(base)
+	s.take()
+
+	// A later expiry notified after take is not compared with the one already taken
+	s.NotifyExpiry(base.Add(time.Hour))
+	if got := s.take(); !got.Equal(base.Add(time.Hour)) {
+		t.Errorf("take() = %v, want %v", got, base.Add(time.Hour))
+	}
+}
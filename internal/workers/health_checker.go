@@ -0,0 +1,170 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Health service names, probe them with the service field of grpc.health.v1.Health/Check
+const (
+	// HealthLiveness is SERVING until shutdown starts, it does not depend on the database
+	HealthLiveness = "liveness"
+	// HealthReadiness is SERVING while Postgres answers and the workers are running and healthy
+	HealthReadiness = "readiness"
+	// healthJobPrefix is followed by a job name, NOT_SERVING while the last run of the job failed
+	healthJobPrefix = "job/"
+)
+
+// Pinger is implemented by pkgGorm.DB
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// HealthChecker keeps the statuses of the gRPC health server in line with the database and the
+// workers. Readiness is also applied to the overall "" service and to the services passed in.
+type HealthChecker struct {
+	l        pkgLog.Logger
+	srv      *health.Server
+	db       Pinger
+	wkrMng   *WorkerManager
+	interval time.Duration
+	timeout  time.Duration
+	services []string
+
+	mu     sync.Mutex
+	status healthpb.HealthCheckResponse_ServingStatus
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+func NewHealthChecker(
+	l pkgLog.Logger,
+	srv *health.Server,
+	db Pinger,
+	wkrMng *WorkerManager,
+	interval time.Duration,
+	timeout time.Duration,
+	services ...string,
+) *HealthChecker {
+	hc := &HealthChecker{
+		l:        l,
+		srv:      srv,
+		db:       db,
+		wkrMng:   wkrMng,
+		interval: interval,
+		timeout:  timeout,
+		services: append([]string{"", HealthReadiness}, services...),
+	}
+
+	// Not ready until the first check passed
+	srv.SetServingStatus(HealthLiveness, healthpb.HealthCheckResponse_SERVING)
+	hc.setReadiness(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return hc
+}
+
+func (hc *HealthChecker) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	doneCh := make(chan struct{})
+
+	hc.mu.Lock()
+	hc.cancel, hc.doneCh = cancel, doneCh
+	hc.mu.Unlock()
+
+	hc.l.Infof(ctx, "Starting health checker (interval=%v)", hc.interval)
+
+	go func() {
+		defer close(doneCh)
+
+		ticker := time.NewTicker(hc.interval)
+		defer ticker.Stop()
+
+		for {
+			hc.check(ctx)
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (hc *HealthChecker) Stop(ctx context.Context) {
+	hc.mu.Lock()
+	cancel, doneCh := hc.cancel, hc.doneCh
+	hc.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-doneCh
+	}
+	hc.l.Info(ctx, "Health checker stopped")
+}
+
+// Healthy implements Worker, the checker reports the others
+func (hc *HealthChecker) Healthy() error {
+	return nil
+}
+
+func (hc *HealthChecker) check(ctx context.Context) {
+	err := hc.ping(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+	if err == nil && !hc.wkrMng.Running() {
+		err = fmt.Errorf("workers are not running")
+	}
+	if err == nil {
+		err = hc.wkrMng.Healthy()
+	}
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	hc.setReadiness(status)
+
+	for _, st := range hc.wkrMng.Jobs() {
+		jobStatus := healthpb.HealthCheckResponse_SERVING
+		if st.LastError != "" {
+			jobStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		hc.srv.SetServingStatus(healthJobPrefix+st.Name, jobStatus)
+	}
+
+	// Only log transitions, the check runs every few seconds
+	hc.mu.Lock()
+	prev := hc.status
+	hc.status = status
+	hc.mu.Unlock()
+
+	if status == prev {
+		return
+	}
+	if err != nil {
+		hc.l.Warnf(ctx, "HealthChecker: not ready: %v", err)
+		return
+	}
+	hc.l.Info(ctx, "HealthChecker: ready")
+}
+
+func (hc *HealthChecker) ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, hc.timeout)
+	defer cancel()
+
+	return hc.db.Ping(ctx)
+}
+
+func (hc *HealthChecker) setReadiness(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, svc := range hc.services {
+		hc.srv.SetServingStatus(svc, status)
+	}
+}
@@ -0,0 +1,163 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+// leaderLockKey is the advisory lock held by the leader replica
+const leaderLockKey int64 = 0x696e765f6c6472 // "inv_ldr"
+
+// LeaderElector elects one replica as leader through a session advisory lock held on a dedicated connection.
+// The lock is a lease: the leader renews it with a heartbeat and steps down when it could not renew
+// for leaseTimeout. Postgres ends the session after leaseTimeout of silence, so a hung leader
+// loses the lock too, and followers take over on their next attempt.
+type LeaderElector struct {
+	l            pkgLog.Logger
+	url          string
+	heartbeat    time.Duration
+	leaseTimeout time.Duration
+	retry        time.Duration
+	leader       atomic.Bool
+
+	mu sync.Mutex
+	// campaignErr is why the last campaign could not reach Postgres, nil once it could
+	campaignErr error
+}
+
+func NewLeaderElector(
+	l pkgLog.Logger,
+	url string,
+	heartbeat time.Duration,
+	leaseTimeout time.Duration,
+	retry time.Duration,
+) *LeaderElector {
+	return &LeaderElector{
+		l:            l,
+		url:          url,
+		heartbeat:    heartbeat,
+		leaseTimeout: leaseTimeout,
+		retry:        retry,
+	}
+}
+
+// IsLeader reports whether this replica currently holds the lease
+func (e *LeaderElector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Run campaigns until ctx is done. Each time the lease is won, onElected is called with a context
+// that is cancelled as soon as the lease is lost. onElected must not block.
+func (e *LeaderElector) Run(ctx context.Context, onElected func(ctx context.Context)) {
+	for ctx.Err() == nil {
+		conn, err := e.campaign(ctx)
+		if err != nil && ctx.Err() != nil {
+			return
+		}
+		e.setCampaignErr(err)
+		if err != nil {
+			e.l.Warnf(ctx, "LeaderElector: campaign failed: %v", err)
+		}
+
+		if conn != nil {
+			e.lead(ctx, conn, onElected)
+			continue
+		}
+
+		select {
+		case <-time.After(e.retry):
+		case <-ctx.Done():
+		}
+	}
+}
+
+// Healthy returns why the last campaign failed. A follower that lost the race for the lock is healthy,
+// one that cannot campaign would never take over from a failed leader.
+func (e *LeaderElector) Healthy() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.campaignErr != nil {
+		return fmt.Errorf("leader campaign failed: %w", e.campaignErr)
+	}
+	return nil
+}
+
+func (e *LeaderElector) setCampaignErr(err error) {
+	e.mu.Lock()
+	e.campaignErr = err
+	e.mu.Unlock()
+}
+
+// campaign tries to take the lock once, it returns the connection holding it or nil
+func (e *LeaderElector) campaign(ctx context.Context) (*pgx.Conn, error) {
+	conn, err := pgx.Connect(ctx, e.url)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server closes the session, and so releases the lock, if heartbeats stop
+	if _, err := conn.Exec(ctx, fmt.Sprintf("SET idle_session_timeout = %d", e.leaseTimeout.Milliseconds())); err != nil {
+		conn.Close(context.Background())
+		return nil, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", leaderLockKey).Scan(&acquired); err != nil {
+		conn.Close(context.Background())
+		return nil, err
+	}
+
+	if !acquired {
+		conn.Close(context.Background())
+		return nil, nil
+	}
+
+	return conn, nil
+}
+
+// lead runs onElected and renews the lease until a heartbeat fails or ctx is done
+func (e *LeaderElector) lead(ctx context.Context, conn *pgx.Conn, onElected func(ctx context.Context)) {
+	leadCtx, cancel := context.WithCancel(ctx)
+	defer func() {
+		e.leader.Store(false)
+		cancel()
+		// Closing the session releases the lock
+		conn.Close(context.Background())
+	}()
+
+	e.leader.Store(true)
+	e.l.Info(ctx, "LeaderElector: became leader")
+	onElected(leadCtx)
+
+	tkr := time.NewTicker(e.heartbeat)
+	defer tkr.Stop()
+
+	for {
+		select {
+		case <-tkr.C:
+			if err := e.renew(leadCtx, conn); err != nil {
+				e.l.Warnf(ctx, "LeaderElector: lost leadership: %v", err)
+				return
+			}
+		case <-ctx.Done():
+			e.l.Info(ctx, "LeaderElector: stepping down")
+			return
+		}
+	}
+}
+
+func (e *LeaderElector) renew(ctx context.Context, conn *pgx.Conn) error {
+	// The previous renewal was a heartbeat ago, give up before the lease runs out
+	ctx, cancel := context.WithTimeout(ctx, e.leaseTimeout-e.heartbeat)
+	defer cancel()
+
+	_, err := conn.Exec(ctx, "SELECT 1")
+	return err
+}
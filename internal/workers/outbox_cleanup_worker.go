@@ -0,0 +1,70 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+// OutboxCleanupWorker deletes published outbox events once they are older than the retention
+type OutboxCleanupWorker struct {
+	l         pkgLog.Logger
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+	oSvc      svc.OutboxService
+}
+
+func NewOutboxCleanupWorker(
+	l pkgLog.Logger,
+	oSvc svc.OutboxService,
+	interval time.Duration,
+	retention time.Duration,
+	batchSize int,
+) *OutboxCleanupWorker {
+	return &OutboxCleanupWorker{
+		l:         l,
+		oSvc:      oSvc,
+		interval:  interval,
+		retention: retention,
+		batchSize: batchSize,
+	}
+}
+
+// Job describes the worker to the scheduler, it only runs on the leader
+func (w *OutboxCleanupWorker) Job() Job {
+	return Job{
+		Name:       "outbox-cleanup",
+		Interval:   w.interval,
+		Timeout:    w.interval,
+		LeaderOnly: true,
+		Run:        w.runJob,
+	}
+}
+
+// runJob deletes batch by batch, so no single statement holds many row locks
+func (w *OutboxCleanupWorker) runJob(ctx context.Context) (int, error) {
+	startTime := time.Now()
+	before := startTime.UTC().Add(-w.retention)
+	total := 0
+
+	for {
+		cnt, err := w.oSvc.DeletePublished(ctx, before, w.batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		total += cnt
+		if cnt < w.batchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.l.Infof(ctx, "OutboxCleanupWorker: deleted %d published events in %v", total, time.Since(startTime))
+	}
+
+	return total, nil
+}
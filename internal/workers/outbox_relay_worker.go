@@ -0,0 +1,63 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+type OutboxRelayWorker struct {
+	l         pkgLog.Logger
+	interval  time.Duration
+	batchSize int
+	oSvc      svc.OutboxService
+}
+
+func NewOutboxRelayWorker(
+	l pkgLog.Logger,
+	oSvc svc.OutboxService,
+	interval time.Duration,
+	batchSize int,
+) *OutboxRelayWorker {
+	return &OutboxRelayWorker{
+		l:         l,
+		oSvc:      oSvc,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Job describes the worker to the scheduler. Every replica relays, the outbox claim keeps one at a time.
+func (w *OutboxRelayWorker) Job() Job {
+	return Job{
+		Name:     "outbox-relay",
+		Interval: w.interval,
+		Run:      w.runJob,
+	}
+}
+
+// runJob drains the outbox, it keeps publishing while batches come back full
+func (w *OutboxRelayWorker) runJob(ctx context.Context) (int, error) {
+	startTime := time.Now()
+	total := 0
+
+	for {
+		cnt, err := w.oSvc.PublishPending(ctx, w.batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		total += cnt
+		if cnt < w.batchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.l.Debugf(ctx, "OutboxRelayWorker: published %d events in %v", total, time.Since(startTime))
+	}
+
+	return total, nil
+}
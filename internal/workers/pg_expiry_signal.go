@@ -0,0 +1,167 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	expiryListenRetryMinWait = 500 * time.Millisecond
+	expiryListenRetryMaxWait = 30 * time.Second
+)
+
+// PgExpirySignal shares the expiry of new holds between replicas through Postgres LISTEN/NOTIFY.
+// Only the leader runs the expiry worker, so a hold taken on a follower has to reach it: every
+// replica listens on the channel and feeds what it receives into its local ExpirySignal.
+// NotifyExpiry never blocks, expiries are coalesced and sent by a background sender.
+type PgExpirySignal struct {
+	l       pkgLog.Logger
+	db      *gorm.DB
+	url     string
+	channel string
+	local   *ExpirySignal
+	pending *ExpirySignal
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	mu sync.Mutex
+	// listenErr is why the listener is disconnected, nil while it listens
+	listenErr error
+}
+
+func NewPgExpirySignal(l pkgLog.Logger, db *gorm.DB, url, channel string, local *ExpirySignal) *PgExpirySignal {
+	return &PgExpirySignal{
+		l:       l,
+		db:      db,
+		url:     url,
+		channel: channel,
+		local:   local,
+		pending: NewExpirySignal(),
+	}
+}
+
+// NotifyExpiry queues at for every replica, it implements service.ExpiryNotifier
+func (s *PgExpirySignal) NotifyExpiry(at time.Time) {
+	s.pending.NotifyExpiry(at)
+}
+
+// Start runs the sender and the listener, it implements Worker
+func (s *PgExpirySignal) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.l.Infof(ctx, "Starting expiry listener: channel=%s", s.channel)
+
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		s.send(ctx)
+	}()
+	go func() {
+		defer s.wg.Done()
+		s.listenLoop(ctx)
+	}()
+}
+
+func (s *PgExpirySignal) Stop(ctx context.Context) {
+	if s.cancel != nil {
+		s.cancel()
+		s.wg.Wait()
+	}
+	s.l.Info(ctx, "Expiry listener shutdown initiated")
+}
+
+// send notifies the earliest queued expiry, expiries queued while a NOTIFY is in flight are coalesced
+func (s *PgExpirySignal) send(ctx context.Context) {
+	for {
+		select {
+		case <-s.pending.C():
+		case <-ctx.Done():
+			return
+		}
+
+		at := s.pending.take()
+		if at.IsZero() {
+			continue
+		}
+
+		if err := s.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", s.channel, at.UTC().Format(time.RFC3339Nano)).Error; err != nil {
+			// Other replicas miss this expiry, but this one should still see it in case it leads
+			s.l.Warnf(ctx, "PgExpirySignal: notify failed: %v", err)
+			s.local.NotifyExpiry(at)
+		}
+	}
+}
+
+func (s *PgExpirySignal) listenLoop(ctx context.Context) {
+	wait := expiryListenRetryMinWait
+	for ctx.Err() == nil {
+		err := s.listen(ctx)
+		if ctx.Err() != nil {
+			break
+		}
+
+		s.setListenErr(err)
+		s.l.Warnf(ctx, "PgExpirySignal: listen failed: %v, retrying in %v", err, wait)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+		}
+		wait = min(wait*2, expiryListenRetryMaxWait)
+	}
+
+	s.l.Info(ctx, "Expiry listener stopped")
+}
+
+// Healthy returns why the listener is disconnected, holds taken on other replicas may expire late meanwhile
+func (s *PgExpirySignal) Healthy() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listenErr != nil {
+		return fmt.Errorf("expiry listener disconnected: %w", s.listenErr)
+	}
+	return nil
+}
+
+func (s *PgExpirySignal) setListenErr(err error) {
+	s.mu.Lock()
+	s.listenErr = err
+	s.mu.Unlock()
+}
+
+// listen holds a dedicated connection, notifications cannot be received through the pool
+func (s *PgExpirySignal) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, s.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{s.channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	s.setListenErr(nil)
+
+	// Expiries sent while we were disconnected are lost, run the worker now so it reloads the earliest one
+	s.local.NotifyExpiry(time.Now())
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		at, err := time.Parse(time.RFC3339Nano, n.Payload)
+		if err != nil {
+			s.l.Warnf(ctx, "PgExpirySignal: invalid payload: %v", err)
+			continue
+		}
+		s.local.NotifyExpiry(at)
+	}
+}
@@ -0,0 +1,61 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+// ReconciliationWorker periodically checks the ticket class counters against the reservations.
+// In dry-run mode it only reports the drift it finds.
+type ReconciliationWorker struct {
+	l        pkgLog.Logger
+	interval time.Duration
+	dryRun   bool
+	rcSvc    svc.ReconciliationService
+}
+
+func NewReconciliationWorker(
+	l pkgLog.Logger,
+	rcSvc svc.ReconciliationService,
+	interval time.Duration,
+	dryRun bool,
+) *ReconciliationWorker {
+	return &ReconciliationWorker{
+		l:        l,
+		rcSvc:    rcSvc,
+		interval: interval,
+		dryRun:   dryRun,
+	}
+}
+
+// Job describes the worker to the scheduler, it only runs on the leader
+func (w *ReconciliationWorker) Job() Job {
+	return Job{
+		Name:       "reconciliation",
+		Interval:   w.interval,
+		Timeout:    w.interval,
+		LeaderOnly: true,
+		Run:        w.runJob,
+	}
+}
+
+// runJob returns the number of drifted ticket classes it found, and the errors of those it could not fix
+func (w *ReconciliationWorker) runJob(ctx context.Context) (int, error) {
+	startTime := time.Now()
+
+	out, err := w.rcSvc.Reconcile(ctx, svc.ReconcileInput{DryRun: w.dryRun})
+	if len(out.Mismatches) == 0 {
+		if err == nil {
+			w.l.Debugf(ctx, "ReconciliationWorker: counters match reservations (took %v)", time.Since(startTime))
+		}
+		return 0, err
+	}
+
+	w.l.Warnf(ctx, "ReconciliationWorker: %d ticket classes drifted, %d fixed in %v (dryRun=%t)",
+		len(out.Mismatches), out.Fixed, time.Since(startTime), w.dryRun)
+
+	return len(out.Mismatches), err
+}
@@ -2,80 +2,124 @@ package workers
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/vogiaan/ticketbottle-inventory/internal/metrics"
 	svc "github.com/vogiaan/ticketbottle-inventory/internal/services"
 	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
 )
 
+// ReservationExpiryWorker releases holds once they expire. It is run by the scheduler: after each run
+// the next one is due when the earliest active hold expires, bounded by minInterval and maxInterval,
+// and a new hold that expires sooner moves it forward.
 type ReservationExpiryWorker struct {
-	l         pkgLog.Logger
-	tkr       *time.Ticker
-	interval  time.Duration
-	batchSize int
-	rSvc      svc.ReservationService
-	doneCh    chan struct{}
+	l           pkgLog.Logger
+	minInterval time.Duration
+	maxInterval time.Duration
+	batchSize   int
+	rSvc        svc.ReservationService
+	sig         *ExpirySignal
+
+	mu     sync.Mutex
+	nextAt time.Time
 }
 
 func NewReservationExpiryWorker(
 	l pkgLog.Logger,
 	rSvc svc.ReservationService,
+	sig *ExpirySignal,
+	minInterval time.Duration,
+	maxInterval time.Duration,
+	batchSize int,
 ) *ReservationExpiryWorker {
 	return &ReservationExpiryWorker{
-		l:         l,
-		rSvc:      rSvc,
-		interval:  1 * time.Minute,
-		batchSize: 500,
-		doneCh:    make(chan struct{}),
+		l:           l,
+		rSvc:        rSvc,
+		sig:         sig,
+		minInterval: minInterval,
+		maxInterval: maxInterval,
+		batchSize:   batchSize,
 	}
 }
 
-func (w *ReservationExpiryWorker) Start(ctx context.Context) {
-	w.tkr = time.NewTicker(w.interval)
-	w.l.Infof(ctx, "Starting ReservationExpiryWorker: interval=%v, batchSize=%d",
-		w.interval, w.batchSize)
-
-	go w.runJob(ctx)
-
-	go func() {
-		for {
-			select {
-			case <-w.tkr.C:
-				w.runJob(ctx)
-			case <-w.doneCh:
-				w.l.Info(ctx, "ReservationExpiryWorker stopped")
-				return
-			case <-ctx.Done():
-				w.l.Info(ctx, "ReservationExpiryWorker context cancelled")
-				return
-			}
-		}
-	}()
+// Job describes the worker to the scheduler
+func (w *ReservationExpiryWorker) Job(timeout time.Duration) Job {
+	return Job{
+		Name:       "reservation-expiry",
+		Schedule:   w,
+		Wake:       w.sig.C(),
+		Timeout:    timeout,
+		LeaderOnly: true,
+		Run:        w.runJob,
+	}
 }
 
-func (w *ReservationExpiryWorker) Stop(ctx context.Context) {
-	if w.tkr != nil {
-		w.tkr.Stop()
+// Next implements cron.Schedule, it is the earliest known expiry clamped to the interval bounds
+func (w *ReservationExpiryWorker) Next(now time.Time) time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if at := w.sig.take(); !at.IsZero() && at.Before(w.nextAt) {
+		w.nextAt = at
 	}
-	close(w.doneCh)
-	w.l.Info(ctx, "ReservationExpiryWorker shutdown initiated")
+
+	delay := min(max(w.nextAt.Sub(now), w.minInterval), w.maxInterval)
+	return now.Add(delay)
 }
 
-func (w *ReservationExpiryWorker) runJob(ctx context.Context) {
+// runJob expires holds batch by batch, it keeps going while batches come back full
+func (w *ReservationExpiryWorker) runJob(ctx context.Context) (int, error) {
 	startTime := time.Now()
+	total := 0
 
 	w.l.Debug(ctx, "ReservationExpiryWorker: starting batch expiration job")
 
-	expCnt, err := w.rSvc.BatchExpireReservations(ctx, w.batchSize)
-	if err != nil {
-		w.l.Errorf(ctx, "ReservationExpiryWorker: batch expiration failed: %v", err)
-		return
-	}
+	for {
+		expCnt, err := w.rSvc.BatchExpireReservations(ctx, w.batchSize)
+		if err != nil {
+			w.backOff()
+			return total, err
+		}
+
+		metrics.ExpiryBatchSize.Observe(float64(expCnt))
 
-	duration := time.Since(startTime)
+		total += expCnt
+		if expCnt < w.batchSize || ctx.Err() != nil {
+			break
+		}
+	}
 
-	if expCnt > 0 {
+	if total > 0 {
 		w.l.Infof(ctx, "ReservationExpiryWorker: expired %d reservations in %v",
-			expCnt, duration)
+			total, time.Since(startTime))
 	}
+
+	next, err := w.rSvc.NextExpiry(ctx)
+	if err != nil {
+		w.backOff()
+		return total, err
+	}
+
+	if next == nil {
+		w.setNext(time.Now().Add(w.maxInterval))
+		return total, nil
+	}
+
+	w.setNext(*next)
+	return total, nil
+}
+
+// setNext records when the next run is due
+func (w *ReservationExpiryWorker) setNext(at time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.nextAt = at
+}
+
+// backOff retries a failed run after maxInterval rather than minInterval, so a database outage
+// is not hammered every few milliseconds. A new hold that expires sooner still wakes the worker.
+func (w *ReservationExpiryWorker) backOff() {
+	w.setNext(time.Now().Add(w.maxInterval))
 }
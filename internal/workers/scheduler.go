@@ -0,0 +1,313 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/vogiaan/ticketbottle-inventory/internal/metrics"
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	panicBackoffMin = 1 * time.Second
+	panicBackoffMax = 5 * time.Minute
+)
+
+var (
+	// ErrJobRunning is returned when a run is requested while the job is still running
+	ErrJobRunning  = errors.New("job is already running")
+	ErrJobNotFound = errors.New("job not found")
+	// ErrNotLeader is returned when a leader-only job is triggered on a follower
+	ErrNotLeader = errors.New("job only runs on the leader replica")
+)
+
+var tracer = otel.Tracer("github.com/vogiaan/ticketbottle-inventory/internal/workers")
+
+// cronParser accepts standard 5 field expressions and descriptors such as @hourly and @every 30s
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// JobFunc is one run of a scheduled job, it returns how many items it processed
+type JobFunc func(ctx context.Context) (int, error)
+
+// Job is a function run by the WorkerManager scheduler. Exactly one of Cron, Interval and Schedule is set.
+type Job struct {
+	Name string
+	// Cron is a cron expression, e.g. "*/5 * * * *" or "@every 1m"
+	Cron string
+	// Interval runs the job every Interval, measured from the end of the previous run
+	Interval time.Duration
+	// Schedule computes the next run after each run and after every Wake
+	Schedule cron.Schedule
+	// Wake makes the scheduler ask Schedule for the next run again, so it can move it forward
+	Wake <-chan struct{}
+	// Timeout cancels the context of a run that takes longer, 0 means no timeout
+	Timeout    time.Duration
+	LeaderOnly bool
+	Run        JobFunc
+}
+
+// JobStatus is the state and the last result of a scheduled job
+type JobStatus struct {
+	Name          string
+	LeaderOnly    bool
+	Active        bool
+	Running       bool
+	Paused        bool
+	NextRun       time.Time
+	LastStart     time.Time
+	LastDuration  time.Duration
+	LastProcessed int
+	LastError     string
+	Runs          int64
+	Failures      int64
+	Skipped       int64
+}
+
+// intervalSchedule runs a job a fixed delay after the previous run ended
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
+
+// scheduledJob runs a Job on its schedule. It implements Worker and can be started again after
+// its context was cancelled, which leader-only jobs rely on.
+type scheduledJob struct {
+	l       pkgLog.Logger
+	job     Job
+	sched   cron.Schedule
+	running atomic.Bool
+	paused  atomic.Bool
+
+	mu       sync.Mutex
+	status   JobStatus
+	panics   int
+	cooldown time.Time
+	cancel   context.CancelFunc
+	doneCh   chan struct{}
+}
+
+func newScheduledJob(l pkgLog.Logger, job Job) (*scheduledJob, error) {
+	if job.Name == "" || job.Run == nil {
+		return nil, fmt.Errorf("job needs a name and a run function")
+	}
+
+	var sched cron.Schedule
+	switch {
+	case job.Cron != "" && job.Interval == 0 && job.Schedule == nil:
+		s, err := cronParser.Parse(job.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression for job %s: %w", job.Name, err)
+		}
+		sched = s
+	case job.Interval > 0 && job.Cron == "" && job.Schedule == nil:
+		sched = intervalSchedule(job.Interval)
+	case job.Schedule != nil && job.Cron == "" && job.Interval == 0:
+		sched = job.Schedule
+	default:
+		return nil, fmt.Errorf("job %s needs exactly one of cron, interval or schedule", job.Name)
+	}
+
+	return &scheduledJob{
+		l:     l,
+		job:   job,
+		sched: sched,
+		status: JobStatus{
+			Name:       job.Name,
+			LeaderOnly: job.LeaderOnly,
+		},
+	}, nil
+}
+
+// Start runs the job until ctx is done. A leader-only job is started again when the lease is won back,
+// possibly before the loop of the lost lease returned: that loop is cancelled and waited for first,
+// so two loops never run the job and the old one cannot mark the new one inactive.
+func (j *scheduledJob) Start(ctx context.Context) {
+	j.mu.Lock()
+	prevCancel, prevDoneCh := j.cancel, j.doneCh
+	j.mu.Unlock()
+
+	if prevCancel != nil {
+		prevCancel()
+		<-prevDoneCh
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	doneCh := make(chan struct{})
+
+	j.mu.Lock()
+	j.cancel, j.doneCh = cancel, doneCh
+	j.status.Active = true
+	j.mu.Unlock()
+
+	j.l.Infof(ctx, "Starting job %s", j.job.Name)
+
+	go func() {
+		defer close(doneCh)
+		defer j.setInactive()
+
+		tmr := time.NewTimer(time.Until(j.next()))
+		defer tmr.Stop()
+
+		for {
+			select {
+			case <-tmr.C:
+				if j.paused.Load() {
+					break
+				}
+				if err := j.runOnce(ctx); errors.Is(err, ErrJobRunning) {
+					j.l.Warnf(ctx, "Job %s: previous run still in progress, skipping", j.job.Name)
+				}
+			case <-j.job.Wake:
+			case <-ctx.Done():
+				j.l.Infof(ctx, "Job %s stopped", j.job.Name)
+				return
+			}
+
+			tmr.Reset(time.Until(j.next()))
+		}
+	}()
+}
+
+func (j *scheduledJob) Stop(ctx context.Context) {
+	j.mu.Lock()
+	cancel, doneCh := j.cancel, j.doneCh
+	j.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-doneCh
+	}
+	j.l.Infof(ctx, "Job %s shutdown initiated", j.job.Name)
+}
+
+// Healthy implements Worker. Failed runs are reported per job by the health checker, not by readiness.
+func (j *scheduledJob) Healthy() error {
+	return nil
+}
+
+// Status returns a copy of the current status
+func (j *scheduledJob) Status() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	st := j.status
+	st.Running = j.running.Load()
+	st.Paused = j.paused.Load()
+	return st
+}
+
+// setPaused skips scheduled runs while paused, a run in progress finishes
+func (j *scheduledJob) setPaused(paused bool) {
+	j.paused.Store(paused)
+}
+
+// next asks the schedule for the next run, pushed back while the job cools down after a panic
+func (j *scheduledJob) next() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	next := j.sched.Next(now)
+	if next.Before(j.cooldown) {
+		next = j.cooldown
+	}
+	if next.Before(now) {
+		next = now
+	}
+
+	j.status.NextRun = next
+	return next
+}
+
+func (j *scheduledJob) setInactive() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.status.Active = false
+	j.status.NextRun = time.Time{}
+}
+
+// runOnce runs the job unless a run is already in progress
+func (j *scheduledJob) runOnce(ctx context.Context) error {
+	if !j.running.CompareAndSwap(false, true) {
+		j.mu.Lock()
+		j.status.Skipped++
+		j.mu.Unlock()
+		return ErrJobRunning
+	}
+	defer j.running.Store(false)
+
+	if j.job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.job.Timeout)
+		defer cancel()
+	}
+
+	ctx, span := tracer.Start(ctx, "job."+j.job.Name)
+	defer span.End()
+
+	start := time.Now()
+	processed, panicked, err := j.call(ctx)
+	dur := time.Since(start)
+
+	span.SetAttributes(attribute.Int("job.processed", processed))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.status.Runs++
+	j.status.LastStart = start
+	j.status.LastDuration = dur
+	j.status.LastProcessed = processed
+	j.status.LastError = ""
+
+	result := "ok"
+	if err != nil {
+		result = "failed"
+		j.status.Failures++
+		j.status.LastError = err.Error()
+		j.l.Errorf(ctx, "Job %s failed after %v: %v", j.job.Name, dur, err)
+	}
+
+	metrics.JobRuns.WithLabelValues(j.job.Name, result).Inc()
+	metrics.JobDuration.WithLabelValues(j.job.Name).Observe(dur.Seconds())
+
+	// Back off exponentially while the job keeps panicking
+	if panicked {
+		j.panics++
+		backoff := min(panicBackoffMin<<min(j.panics-1, 16), panicBackoffMax)
+		j.cooldown = time.Now().Add(backoff)
+		j.l.Warnf(ctx, "Job %s: panicked %d times in a row, next run not before %v", j.job.Name, j.panics, backoff)
+	} else {
+		j.panics = 0
+	}
+
+	return err
+}
+
+// call runs the job function and turns a panic into an error
+func (j *scheduledJob) call(ctx context.Context) (processed int, panicked bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			j.l.Errorf(ctx, "Job %s panicked: %v\n%s", j.job.Name, r, debug.Stack())
+			processed, panicked, err = 0, true, fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	processed, err = j.job.Run(ctx)
+	return processed, false, err
+}
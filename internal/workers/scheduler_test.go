@@ -0,0 +1,196 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+func TestNewScheduledJob(t *testing.T) {
+	run := func(ctx context.Context) (int, error) { return 0, nil }
+
+	tests := []struct {
+		name    string
+		job     Job
+		wantErr bool
+	}{
+		{name: "cron", job: Job{Name: "j", Cron: "*/5 * * * *", Run: run}},
+		{name: "descriptor", job: Job{Name: "j", Cron: "@every 30s", Run: run}},
+		{name: "interval", job: Job{Name: "j", Interval: time.Second, Run: run}},
+		{name: "schedule", job: Job{Name: "j", Schedule: intervalSchedule(time.Second), Run: run}},
+		{name: "no name", job: Job{Interval: time.Second, Run: run}, wantErr: true},
+		{name: "no run function", job: Job{Name: "j", Interval: time.Second}, wantErr: true},
+		{name: "no schedule", job: Job{Name: "j", Run: run}, wantErr: true},
+		{name: "cron and interval", job: Job{Name: "j", Cron: "@hourly", Interval: time.Second, Run: run}, wantErr: true},
+		{name: "interval and schedule", job: Job{Name: "j", Interval: time.Second, Schedule: intervalSchedule(time.Second), Run: run}, wantErr: true},
+		{name: "invalid cron", job: Job{Name: "j", Cron: "every minute", Run: run}, wantErr: true},
+		{name: "seconds field is not accepted", job: Job{Name: "j", Cron: "*/5 * * * * *", Run: run}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newScheduledJob(pkgLog.InitializeTestZapLogger(), tt.job)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newScheduledJob() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunOnceSkipsOverlappingRun(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	j := newTestJob(t, func(ctx context.Context) (int, error) {
+		close(started)
+		<-release
+		return 3, nil
+	})
+
+	done := make(chan error)
+	go func() { done <- j.runOnce(context.Background()) }()
+	<-started
+
+	if err := j.runOnce(context.Background()); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("overlapping runOnce() error = %v, want ErrJobRunning", err)
+	}
+	if st := j.Status(); !st.Running || st.Skipped != 1 {
+		t.Errorf("status while running = (running %t, skipped %d), want (true, 1)", st.Running, st.Skipped)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("first runOnce() error = %v", err)
+	}
+
+	st := j.Status()
+	if st.Running || st.Runs != 1 || st.Skipped != 1 || st.LastProcessed != 3 {
+		t.Errorf("status after run = (running %t, runs %d, skipped %d, processed %d), want (false, 1, 1, 3)",
+			st.Running, st.Runs, st.Skipped, st.LastProcessed)
+	}
+}
+
+func TestRunOncePanicBackoff(t *testing.T) {
+	// outcomes are the results of consecutive runs, backoff is the cooldown expected after each
+	tests := []struct {
+		name     string
+		outcomes []string
+		backoff  []time.Duration
+	}{
+		{name: "single panic", outcomes: []string{"panic"}, backoff: []time.Duration{time.Second}},
+		{name: "doubles while panicking", outcomes: []string{"panic", "panic", "panic"}, backoff: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}},
+		{name: "capped", outcomes: []string{"panic", "panic", "panic", "panic", "panic", "panic", "panic", "panic", "panic", "panic"},
+			backoff: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 32 * time.Second, 64 * time.Second, 128 * time.Second, 256 * time.Second, panicBackoffMax}},
+		{name: "error does not back off", outcomes: []string{"error"}, backoff: []time.Duration{0}},
+		{name: "success resets the count", outcomes: []string{"panic", "panic", "ok", "panic"}, backoff: []time.Duration{time.Second, 2 * time.Second, 0, time.Second}},
+		{name: "error resets the count", outcomes: []string{"panic", "error", "panic"}, backoff: []time.Duration{time.Second, 0, time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var outcome string
+			j := newTestJob(t, func(ctx context.Context) (int, error) {
+				switch outcome {
+				case "panic":
+					panic("boom")
+				case "error":
+					return 0, errors.New("failed")
+				}
+				return 1, nil
+			})
+
+			for i, o := range tt.outcomes {
+				outcome = o
+				before := time.Now()
+				cooldown := j.cooldown
+
+				err := j.runOnce(context.Background())
+				if (err != nil) != (o != "ok") {
+					t.Fatalf("run %d: runOnce() error = %v", i, err)
+				}
+
+				want := tt.backoff[i]
+				if want == 0 {
+					if !j.cooldown.Equal(cooldown) {
+						t.Errorf("run %d: cooldown moved to %v after a run that did not panic", i, j.cooldown)
+					}
+					continue
+				}
+
+				if got := j.cooldown.Sub(before); got < want || got > want+time.Second {
+					t.Errorf("run %d: backoff = %v, want %v", i, got, want)
+				}
+				if next := j.next(); next.Before(j.cooldown) {
+					t.Errorf("run %d: next() = %v, before the cooldown %v", i, next, j.cooldown)
+				}
+			}
+
+			if st := j.Status(); st.Runs != int64(len(tt.outcomes)) {
+				t.Errorf("Runs = %d, want %d", st.Runs, len(tt.outcomes))
+			}
+		})
+	}
+}
+
+// TestStartWaitsForPreviousLoop starts a job again after its context was cancelled, like a leader that
+// lost and won back the lease, while the run of the old loop is still finishing
+func TestStartWaitsForPreviousLoop(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	j := newTestJob(t, func(ctx context.Context) (int, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			// A run that does not stop as soon as its context is cancelled
+			<-release
+		}
+		return 0, nil
+	})
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	j.Start(ctx1)
+	<-started
+	cancel1()
+
+	restarted := make(chan struct{})
+	go func() {
+		j.Start(context.Background())
+		close(restarted)
+	}()
+	t.Cleanup(func() { j.Stop(context.Background()) })
+
+	select {
+	case <-restarted:
+		t.Fatal("Start() returned while the previous loop was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-restarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start() did not return after the previous loop ended")
+	}
+
+	if !j.Status().Active {
+		t.Error("Status().Active = false after the job was started again")
+	}
+}
+
+func newTestJob(t *testing.T, run JobFunc) *scheduledJob {
+	t.Helper()
+
+	j, err := newScheduledJob(pkgLog.InitializeTestZapLogger(), Job{
+		Name:     "test",
+		Interval: time.Millisecond,
+		Run:      run,
+	})
+	if err != nil {
+		t.Fatalf("newScheduledJob(): %v", err)
+	}
+	return j
+}
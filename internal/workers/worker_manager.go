@@ -2,9 +2,11 @@ package workers
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -14,18 +16,29 @@ import (
 type Worker interface {
 	Start(ctx context.Context)
 	Stop(ctx context.Context)
+	// Healthy returns why the worker cannot do its work right now, e.g. a lost connection it is
+	// still trying to restore. It is folded into readiness.
+	Healthy() error
 }
 
 type WorkerManager struct {
-	l    pkgLog.Logger
-	wkrs []Worker
-	wg   sync.WaitGroup
+	l          pkgLog.Logger
+	wkrs       []Worker
+	leaderWkrs []Worker
+	jobs       []*scheduledJob
+	elector    *LeaderElector
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	running    atomic.Bool
 }
 
-func NewWorkerManager(l pkgLog.Logger) *WorkerManager {
+// NewWorkerManager creates a manager. Without an elector leader-only workers run on every replica.
+func NewWorkerManager(l pkgLog.Logger, elector *LeaderElector) *WorkerManager {
 	return &WorkerManager{
-		l:    l,
-		wkrs: make([]Worker, 0),
+		l:          l,
+		wkrs:       make([]Worker, 0),
+		leaderWkrs: make([]Worker, 0),
+		elector:    elector,
 	}
 }
 
@@ -33,26 +46,168 @@ func (wm *WorkerManager) Register(worker Worker) {
 	wm.wkrs = append(wm.wkrs, worker)
 }
 
+// RegisterLeaderOnly adds a worker that only runs on the leader replica. It is started each time
+// the lease is won and its context is cancelled when the lease is lost, so it must exit on ctx.Done
+// and support being started again.
+func (wm *WorkerManager) RegisterLeaderOnly(worker Worker) {
+	wm.leaderWkrs = append(wm.leaderWkrs, worker)
+}
+
+// Schedule registers a job run on its cron expression, interval or custom schedule.
+// Runs never overlap, panics are recovered and the last result is kept for Jobs.
+func (wm *WorkerManager) Schedule(job Job) error {
+	sj, err := newScheduledJob(wm.l, job)
+	if err != nil {
+		return err
+	}
+
+	wm.jobs = append(wm.jobs, sj)
+	if job.LeaderOnly {
+		wm.RegisterLeaderOnly(sj)
+	} else {
+		wm.Register(sj)
+	}
+
+	return nil
+}
+
+// Jobs returns the status of every scheduled job in registration order
+func (wm *WorkerManager) Jobs() []JobStatus {
+	sts := make([]JobStatus, len(wm.jobs))
+	for i, sj := range wm.jobs {
+		sts[i] = sj.Status()
+	}
+	return sts
+}
+
+// Running reports whether the workers were started and are not being stopped
+func (wm *WorkerManager) Running() bool {
+	return wm.running.Load()
+}
+
+// Healthy returns the first error reported by a worker or by the leader campaign
+func (wm *WorkerManager) Healthy() error {
+	for _, wkrs := range [][]Worker{wm.wkrs, wm.leaderWkrs} {
+		for _, worker := range wkrs {
+			if err := worker.Healthy(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if wm.elector != nil && len(wm.leaderWkrs) > 0 {
+		return wm.elector.Healthy()
+	}
+	return nil
+}
+
+// IsLeader reports whether leader-only workers run on this replica
+func (wm *WorkerManager) IsLeader() bool {
+	return wm.elector == nil || wm.elector.IsLeader()
+}
+
+// Trigger runs a job right away and returns its status after the run. A failed run is reported
+// in the status, the error is only set when the job could not be started.
+func (wm *WorkerManager) Trigger(ctx context.Context, name string) (JobStatus, error) {
+	sj, err := wm.job(name)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	if sj.job.LeaderOnly && wm.elector != nil && !wm.elector.IsLeader() {
+		return sj.Status(), ErrNotLeader
+	}
+
+	if err := sj.runOnce(ctx); errors.Is(err, ErrJobRunning) {
+		return sj.Status(), err
+	}
+
+	return sj.Status(), nil
+}
+
+// Pause stops the scheduled runs of a job on this replica until Resume, Trigger still runs it
+func (wm *WorkerManager) Pause(name string) (JobStatus, error) {
+	sj, err := wm.job(name)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	sj.setPaused(true)
+	wm.l.Warnf(context.Background(), "Job %s paused", name)
+	return sj.Status(), nil
+}
+
+func (wm *WorkerManager) Resume(name string) (JobStatus, error) {
+	sj, err := wm.job(name)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	sj.setPaused(false)
+	wm.l.Infof(context.Background(), "Job %s resumed", name)
+	return sj.Status(), nil
+}
+
+func (wm *WorkerManager) job(name string) (*scheduledJob, error) {
+	for _, sj := range wm.jobs {
+		if sj.job.Name == name {
+			return sj, nil
+		}
+	}
+	return nil, ErrJobNotFound
+}
+
 func (wm *WorkerManager) StartAll(ctx context.Context) {
-	wm.l.Infof(ctx, "Starting %d workers", len(wm.wkrs))
+	ctx, wm.cancel = context.WithCancel(ctx)
+	wm.l.Infof(ctx, "Starting %d workers, %d leader-only", len(wm.wkrs), len(wm.leaderWkrs))
 
-	for _, worker := range wm.wkrs {
+	wm.running.Store(true)
+
+	wkrs := wm.wkrs
+	if wm.elector == nil {
+		wkrs = append(wkrs, wm.leaderWkrs...)
+	}
+	wm.startWorkers(ctx, wkrs)
+
+	if wm.elector != nil && len(wm.leaderWkrs) > 0 {
+		wm.wg.Add(1)
+		go func() {
+			defer wm.wg.Done()
+			wm.elector.Run(ctx, func(leadCtx context.Context) {
+				wm.l.Infof(ctx, "Starting %d leader-only workers", len(wm.leaderWkrs))
+				wm.startWorkers(leadCtx, wm.leaderWkrs)
+			})
+		}()
+	}
+
+	wm.l.Info(ctx, "All workers started")
+}
+
+func (wm *WorkerManager) startWorkers(ctx context.Context, wkrs []Worker) {
+	for _, worker := range wkrs {
 		wm.wg.Add(1)
 		go func(w Worker) {
 			defer wm.wg.Done()
 			w.Start(ctx)
 		}(worker)
 	}
-
-	wm.l.Info(ctx, "All workers started")
 }
 
 func (wm *WorkerManager) StopAll(ctx context.Context) {
-	wm.l.Infof(ctx, "Stopping %d workers", len(wm.wkrs))
+	wm.running.Store(false)
+	wm.l.Infof(ctx, "Stopping %d workers", len(wm.wkrs)+len(wm.leaderWkrs))
 
 	for _, worker := range wm.wkrs {
 		worker.Stop(ctx)
 	}
+	for _, worker := range wm.leaderWkrs {
+		worker.Stop(ctx)
+	}
+
+	// Ends the campaign, a leader releases its lease right away
+	if wm.cancel != nil {
+		wm.cancel()
+	}
 
 	done := make(chan struct{})
 	go func() {
@@ -0,0 +1,67 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pkgLog "github.com/vogiaan/ticketbottle-inventory/pkg/logger"
+)
+
+type fakeWorker struct {
+	err error
+}
+
+func (w *fakeWorker) Start(ctx context.Context) {}
+func (w *fakeWorker) Stop(ctx context.Context)  {}
+func (w *fakeWorker) Healthy() error            { return w.err }
+
+func TestWorkerManagerHealthy(t *testing.T) {
+	errDisconnected := errors.New("listener disconnected")
+
+	tests := []struct {
+		name    string
+		wkrs    []*fakeWorker
+		leaders []*fakeWorker
+		want    error
+	}{
+		{name: "no workers"},
+		{name: "all healthy", wkrs: []*fakeWorker{{}, {}}, leaders: []*fakeWorker{{}}},
+		{name: "unhealthy worker", wkrs: []*fakeWorker{{}, {err: errDisconnected}}, want: errDisconnected},
+		{name: "unhealthy leader-only worker", wkrs: []*fakeWorker{{}}, leaders: []*fakeWorker{{err: errDisconnected}}, want: errDisconnected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wm := NewWorkerManager(pkgLog.InitializeTestZapLogger(), nil)
+			for _, w := range tt.wkrs {
+				wm.Register(w)
+			}
+			for _, w := range tt.leaders {
+				wm.RegisterLeaderOnly(w)
+			}
+
+			if err := wm.Healthy(); !errors.Is(err, tt.want) {
+				t.Errorf("Healthy() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeaderElectorHealthy(t *testing.T) {
+	e := NewLeaderElector(pkgLog.InitializeTestZapLogger(), "", 0, 0, 0)
+	wm := NewWorkerManager(pkgLog.InitializeTestZapLogger(), e)
+	wm.RegisterLeaderOnly(&fakeWorker{})
+
+	errCampaign := errors.New("connection refused")
+	e.setCampaignErr(errCampaign)
+	if err := wm.Healthy(); !errors.Is(err, errCampaign) {
+		t.Errorf("Healthy() = %v, want %v", err, errCampaign)
+	}
+
+	// Losing the race for the lock is not an error
+	e.setCampaignErr(nil)
+	if err := wm.Healthy(); err != nil {
+		t.Errorf("Healthy() = %v, want nil", err)
+	}
+}
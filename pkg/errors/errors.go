@@ -9,6 +9,17 @@ import (
 type GRPCError struct {
 	GrpcCode codes.Code
 	Message  string
+	// Reason and Metadata are sent as google.rpc.ErrorInfo when Reason is set
+	Reason   string
+	Metadata map[string]string
+	// FieldViolations are sent as google.rpc.BadRequest
+	FieldViolations []FieldViolation
+}
+
+// FieldViolation describes one invalid request field, Field is the path of the field such as items[0].quantity
+type FieldViolation struct {
+	Field       string
+	Description string
 }
 
 func (e *GRPCError) Error() string {
@@ -22,6 +33,28 @@ func NewGRPCError(code codes.Code, message string) *GRPCError {
 	}
 }
 
+// WithInfo returns a copy of the error that carries a machine readable reason and metadata
+func (e *GRPCError) WithInfo(reason string, metadata map[string]string) *GRPCError {
+	return &GRPCError{
+		GrpcCode:        e.GrpcCode,
+		Message:         e.Message,
+		Reason:          reason,
+		Metadata:        metadata,
+		FieldViolations: e.FieldViolations,
+	}
+}
+
+// WithFieldViolations returns a copy of the error that reports which request fields are invalid
+func (e *GRPCError) WithFieldViolations(violations []FieldViolation) *GRPCError {
+	return &GRPCError{
+		GrpcCode:        e.GrpcCode,
+		Message:         e.Message,
+		Reason:          e.Reason,
+		Metadata:        e.Metadata,
+		FieldViolations: violations,
+	}
+}
+
 func NewGRPCErrorf(code codes.Code, format string, args ...interface{}) *GRPCError {
 	return &GRPCError{
 		GrpcCode: code,
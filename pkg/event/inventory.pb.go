@@ -0,0 +1,531 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: event/inventory.proto
+
+package event
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReservationItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationItem) Reset() {
+	*x = ReservationItem{}
+	mi := &file_event_inventory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationItem) ProtoMessage() {}
+
+func (x *ReservationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_event_inventory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationItem.ProtoReflect.Descriptor instead.
+func (*ReservationItem) Descriptor() ([]byte, []int) {
+	return file_event_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReservationItem) GetTicketClassId() string {
+	if x != nil {
+		return x.TicketClassId
+	}
+	return ""
+}
+
+func (x *ReservationItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ReservationCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationCreated) Reset() {
+	*x = ReservationCreated{}
+	mi := &file_event_inventory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationCreated) ProtoMessage() {}
+
+func (x *ReservationCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_event_inventory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationCreated.ProtoReflect.Descriptor instead.
+func (*ReservationCreated) Descriptor() ([]byte, []int) {
+	return file_event_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReservationCreated) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *ReservationCreated) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReservationCreated) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ReservationCreated) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ReservationConfirmed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	ConfirmedAt   string                 `protobuf:"bytes,3,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationConfirmed) Reset() {
+	*x = ReservationConfirmed{}
+	mi := &file_event_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationConfirmed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationConfirmed) ProtoMessage() {}
+
+func (x *ReservationConfirmed) ProtoReflect() protoreflect.Message {
+	mi := &file_event_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationConfirmed.ProtoReflect.Descriptor instead.
+func (*ReservationConfirmed) Descriptor() ([]byte, []int) {
+	return file_event_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReservationConfirmed) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *ReservationConfirmed) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReservationConfirmed) GetConfirmedAt() string {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return ""
+}
+
+type ReservationCancelled struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	CancelledAt   string                 `protobuf:"bytes,4,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationCancelled) Reset() {
+	*x = ReservationCancelled{}
+	mi := &file_event_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationCancelled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationCancelled) ProtoMessage() {}
+
+func (x *ReservationCancelled) ProtoReflect() protoreflect.Message {
+	mi := &file_event_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationCancelled.ProtoReflect.Descriptor instead.
+func (*ReservationCancelled) Descriptor() ([]byte, []int) {
+	return file_event_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReservationCancelled) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *ReservationCancelled) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReservationCancelled) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReservationCancelled) GetCancelledAt() string {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return ""
+}
+
+type ReservationExpired struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	ExpiredAt     string                 `protobuf:"bytes,3,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationExpired) Reset() {
+	*x = ReservationExpired{}
+	mi := &file_event_inventory_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationExpired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationExpired) ProtoMessage() {}
+
+func (x *ReservationExpired) ProtoReflect() protoreflect.Message {
+	mi := &file_event_inventory_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationExpired.ProtoReflect.Descriptor instead.
+func (*ReservationExpired) Descriptor() ([]byte, []int) {
+	return file_event_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReservationExpired) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *ReservationExpired) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReservationExpired) GetExpiredAt() string {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return ""
+}
+
+type CapacityChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	EventId       string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	PreviousTotal int32                  `protobuf:"varint,3,opt,name=previous_total,json=previousTotal,proto3" json:"previous_total,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Reserved      int32                  `protobuf:"varint,5,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Sold          int32                  `protobuf:"varint,6,opt,name=sold,proto3" json:"sold,omitempty"`
+	Oversold      int32                  `protobuf:"varint,7,opt,name=oversold,proto3" json:"oversold,omitempty"`
+	ChangedAt     string                 `protobuf:"bytes,8,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapacityChanged) Reset() {
+	*x = CapacityChanged{}
+	mi := &file_event_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapacityChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacityChanged) ProtoMessage() {}
+
+func (x *CapacityChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_event_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapacityChanged.ProtoReflect.Descriptor instead.
+func (*CapacityChanged) Descriptor() ([]byte, []int) {
+	return file_event_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CapacityChanged) GetTicketClassId() string {
+	if x != nil {
+		return x.TicketClassId
+	}
+	return ""
+}
+
+func (x *CapacityChanged) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *CapacityChanged) GetPreviousTotal() int32 {
+	if x != nil {
+		return x.PreviousTotal
+	}
+	return 0
+}
+
+func (x *CapacityChanged) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CapacityChanged) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *CapacityChanged) GetSold() int32 {
+	if x != nil {
+		return x.Sold
+	}
+	return 0
+}
+
+func (x *CapacityChanged) GetOversold() int32 {
+	if x != nil {
+		return x.Oversold
+	}
+	return 0
+}
+
+func (x *CapacityChanged) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+var File_event_inventory_proto protoreflect.FileDescriptor
+
+const file_event_inventory_proto_rawDesc = "" +
+	"\n" +
+	"\x15event/inventory.proto\x12\x0finventory.event\"U\n" +
+	"\x0fReservationItem\x12&\n" +
+	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xa9\x01\n" +
+	"\x12ReservationCreated\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x126\n" +
+	"\x05items\x18\x02 \x03(\v2 .inventory.event.ReservationItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\tR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\x90\x01\n" +
+	"\x14ReservationConfirmed\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x126\n" +
+	"\x05items\x18\x02 \x03(\v2 .inventory.event.ReservationItemR\x05items\x12!\n" +
+	"\fconfirmed_at\x18\x03 \x01(\tR\vconfirmedAt\"\xa8\x01\n" +
+	"\x14ReservationCancelled\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x126\n" +
+	"\x05items\x18\x02 \x03(\v2 .inventory.event.ReservationItemR\x05items\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12!\n" +
+	"\fcancelled_at\x18\x04 \x01(\tR\vcancelledAt\"\x8a\x01\n" +
+	"\x12ReservationExpired\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x126\n" +
+	"\x05items\x18\x02 \x03(\v2 .inventory.event.ReservationItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"expired_at\x18\x03 \x01(\tR\texpiredAt\"\xfc\x01\n" +
+	"\x0fCapacityChanged\x12&\n" +
+	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12%\n" +
+	"\x0eprevious_total\x18\x03 \x01(\x05R\rpreviousTotal\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x1a\n" +
+	"\breserved\x18\x05 \x01(\x05R\breserved\x12\x12\n" +
+	"\x04sold\x18\x06 \x01(\x05R\x04sold\x12\x1a\n" +
+	"\boversold\x18\a \x01(\x05R\boversold\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\b \x01(\tR\tchangedAtB7Z5github.com/vogiaan1904/ticketbottle-proto/proto/eventb\x06proto3"
+
+var (
+	file_event_inventory_proto_rawDescOnce sync.Once
+	file_event_inventory_proto_rawDescData []byte
+)
+
+func file_event_inventory_proto_rawDescGZIP() []byte {
+	file_event_inventory_proto_rawDescOnce.Do(func() {
+		file_event_inventory_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_event_inventory_proto_rawDesc), len(file_event_inventory_proto_rawDesc)))
+	})
+	return file_event_inventory_proto_rawDescData
+}
+
+var file_event_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_event_inventory_proto_goTypes = []any{
+	(*ReservationItem)(nil),      // 0: inventory.event.ReservationItem
+	(*ReservationCreated)(nil),   // 1: inventory.event.ReservationCreated
+	(*ReservationConfirmed)(nil), // 2: inventory.event.ReservationConfirmed
+	(*ReservationCancelled)(nil), // 3: inventory.event.ReservationCancelled
+	(*ReservationExpired)(nil),   // 4: inventory.event.ReservationExpired
+	(*CapacityChanged)(nil),      // 5: inventory.event.CapacityChanged
+}
+var file_event_inventory_proto_depIdxs = []int32{
+	0, // 0: inventory.event.ReservationCreated.items:type_name -> inventory.event.ReservationItem
+	0, // 1: inventory.event.ReservationConfirmed.items:type_name -> inventory.event.ReservationItem
+	0, // 2: inventory.event.ReservationCancelled.items:type_name -> inventory.event.ReservationItem
+	0, // 3: inventory.event.ReservationExpired.items:type_name -> inventory.event.ReservationItem
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_event_inventory_proto_init() }
+func file_event_inventory_proto_init() {
+	if File_event_inventory_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_inventory_proto_rawDesc), len(file_event_inventory_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_event_inventory_proto_goTypes,
+		DependencyIndexes: file_event_inventory_proto_depIdxs,
+		MessageInfos:      file_event_inventory_proto_msgTypes,
+	}.Build()
+	File_event_inventory_proto = out.File
+	file_event_inventory_proto_goTypes = nil
+	file_event_inventory_proto_depIdxs = nil
+}
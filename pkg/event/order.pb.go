@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: event/order.proto
+
+package event
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrderPaid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	PaidAt        string                 `protobuf:"bytes,2,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderPaid) Reset() {
+	*x = OrderPaid{}
+	mi := &file_event_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderPaid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderPaid) ProtoMessage() {}
+
+func (x *OrderPaid) ProtoReflect() protoreflect.Message {
+	mi := &file_event_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderPaid.ProtoReflect.Descriptor instead.
+func (*OrderPaid) Descriptor() ([]byte, []int) {
+	return file_event_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrderPaid) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *OrderPaid) GetPaidAt() string {
+	if x != nil {
+		return x.PaidAt
+	}
+	return ""
+}
+
+type OrderCancelled struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	CancelledAt   string                 `protobuf:"bytes,3,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderCancelled) Reset() {
+	*x = OrderCancelled{}
+	mi := &file_event_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderCancelled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderCancelled) ProtoMessage() {}
+
+func (x *OrderCancelled) ProtoReflect() protoreflect.Message {
+	mi := &file_event_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderCancelled.ProtoReflect.Descriptor instead.
+func (*OrderCancelled) Descriptor() ([]byte, []int) {
+	return file_event_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderCancelled) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *OrderCancelled) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderCancelled) GetCancelledAt() string {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return ""
+}
+
+type OrderFailed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	FailedAt      string                 `protobuf:"bytes,3,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderFailed) Reset() {
+	*x = OrderFailed{}
+	mi := &file_event_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFailed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFailed) ProtoMessage() {}
+
+func (x *OrderFailed) ProtoReflect() protoreflect.Message {
+	mi := &file_event_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFailed.ProtoReflect.Descriptor instead.
+func (*OrderFailed) Descriptor() ([]byte, []int) {
+	return file_event_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderFailed) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *OrderFailed) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderFailed) GetFailedAt() string {
+	if x != nil {
+		return x.FailedAt
+	}
+	return ""
+}
+
+var File_event_order_proto protoreflect.FileDescriptor
+
+const file_event_order_proto_rawDesc = "" +
+	"\n" +
+	"\x11event/order.proto\x12\vorder.event\"C\n" +
+	"\tOrderPaid\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x12\x17\n" +
+	"\apaid_at\x18\x02 \x01(\tR\x06paidAt\"j\n" +
+	"\x0eOrderCancelled\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12!\n" +
+	"\fcancelled_at\x18\x03 \x01(\tR\vcancelledAt\"a\n" +
+	"\vOrderFailed\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1b\n" +
+	"\tfailed_at\x18\x03 \x01(\tR\bfailedAtB7Z5github.com/vogiaan1904/ticketbottle-proto/proto/eventb\x06proto3"
+
+var (
+	file_event_order_proto_rawDescOnce sync.Once
+	file_event_order_proto_rawDescData []byte
+)
+
+func file_event_order_proto_rawDescGZIP() []byte {
+	file_event_order_proto_rawDescOnce.Do(func() {
+		file_event_order_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_event_order_proto_rawDesc), len(file_event_order_proto_rawDesc)))
+	})
+	return file_event_order_proto_rawDescData
+}
+
+var file_event_order_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_event_order_proto_goTypes = []any{
+	(*OrderPaid)(nil),      // 0: order.event.OrderPaid
+	(*OrderCancelled)(nil), // 1: order.event.OrderCancelled
+	(*OrderFailed)(nil),    // 2: order.event.OrderFailed
+}
+var file_event_order_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_event_order_proto_init() }
+func file_event_order_proto_init() {
+	if File_event_order_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_order_proto_rawDesc), len(file_event_order_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_event_order_proto_goTypes,
+		DependencyIndexes: file_event_order_proto_depIdxs,
+		MessageInfos:      file_event_order_proto_msgTypes,
+	}.Build()
+	File_event_order_proto = out.File
+	file_event_order_proto_goTypes = nil
+	file_event_order_proto_depIdxs = nil
+}
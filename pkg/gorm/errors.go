@@ -0,0 +1,127 @@
+package gorm
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Classified Postgres errors, match them with errors.Is
+var (
+	ErrUniqueViolation      = errors.New("unique constraint violation")
+	ErrForeignKeyViolation  = errors.New("foreign key constraint violation")
+	ErrCheckViolation       = errors.New("check constraint violation")
+	ErrSerializationFailure = errors.New("serialization failure")
+	ErrDeadlockDetected     = errors.New("deadlock detected")
+	ErrLockNotAvailable     = errors.New("lock not available")
+	ErrUnavailable          = errors.New("database unavailable")
+)
+
+// PgError keeps the driver error and adds the class it was sorted into
+type PgError struct {
+	SQLState   string
+	Constraint string
+	kind       error
+	err        error
+}
+
+func (e *PgError) Error() string {
+	return e.err.Error()
+}
+
+func (e *PgError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// Classify sorts a pgx error by SQLSTATE. Errors that are not from Postgres,
+// already classified or of an unknown state are returned unchanged.
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var classified *PgError
+	if errors.As(err, &classified) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	kind := classifySQLState(pgErr.Code)
+	if kind == nil {
+		return err
+	}
+
+	return &PgError{
+		SQLState:   pgErr.Code,
+		Constraint: pgErr.ConstraintName,
+		kind:       kind,
+		err:        err,
+	}
+}
+
+func classifySQLState(code string) error {
+	switch code {
+	case "23505":
+		return ErrUniqueViolation
+	case "23503":
+		return ErrForeignKeyViolation
+	case "23514":
+		return ErrCheckViolation
+	case "40001":
+		return ErrSerializationFailure
+	case "40P01":
+		return ErrDeadlockDetected
+	case "55P03":
+		return ErrLockNotAvailable
+	case "53300", "57P01", "57P02", "57P03":
+		// too_many_connections, admin_shutdown, crash_shutdown, cannot_connect_now
+		return ErrUnavailable
+	}
+
+	// Class 08 is connection exception
+	if strings.HasPrefix(code, "08") {
+		return ErrUnavailable
+	}
+
+	return nil
+}
+
+// IsRetryable reports whether running the whole transaction again may succeed
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrSerializationFailure) ||
+		errors.Is(err, ErrDeadlockDetected) ||
+		errors.Is(err, ErrLockNotAvailable)
+}
+
+// registerErrorClassifier classifies the error of every statement so callers can use errors.Is
+func registerErrorClassifier(db *gorm.DB) error {
+	classify := func(tx *gorm.DB) {
+		if tx.Error != nil {
+			tx.Error = Classify(tx.Error)
+		}
+	}
+
+	cb := db.Callback()
+	if err := cb.Create().After("gorm:create").Register("pkggorm:classify_error", classify); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register("pkggorm:classify_error", classify); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("pkggorm:classify_error", classify); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register("pkggorm:classify_error", classify); err != nil {
+		return err
+	}
+	if err := cb.Row().After("gorm:row").Register("pkggorm:classify_error", classify); err != nil {
+		return err
+	}
+	return cb.Raw().After("gorm:raw").Register("pkggorm:classify_error", classify)
+}
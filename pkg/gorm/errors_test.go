@@ -0,0 +1,84 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		want       error
+		constraint string
+		retryable  bool
+	}{
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505", ConstraintName: "idx_event_name"}, want: ErrUniqueViolation, constraint: "idx_event_name"},
+		{name: "foreign key violation", err: &pgconn.PgError{Code: "23503"}, want: ErrForeignKeyViolation},
+		{name: "check violation", err: &pgconn.PgError{Code: "23514", ConstraintName: "chk_ticket_class_capacity"}, want: ErrCheckViolation, constraint: "chk_ticket_class_capacity"},
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, want: ErrSerializationFailure, retryable: true},
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, want: ErrDeadlockDetected, retryable: true},
+		{name: "lock not available", err: &pgconn.PgError{Code: "55P03"}, want: ErrLockNotAvailable, retryable: true},
+		{name: "too many connections", err: &pgconn.PgError{Code: "53300"}, want: ErrUnavailable},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, want: ErrUnavailable},
+		{name: "connection exception", err: &pgconn.PgError{Code: "08006"}, want: ErrUnavailable},
+		{name: "wrapped", err: fmt.Errorf("insert: %w", &pgconn.PgError{Code: "40P01"}), want: ErrDeadlockDetected, retryable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Classify(tt.err)
+
+			if !errors.Is(got, tt.want) {
+				t.Errorf("Classify() = %v, want it to match %v", got, tt.want)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("Classify() = %v, want it to keep the driver error", got)
+			}
+
+			var pgErr *PgError
+			if !errors.As(got, &pgErr) {
+				t.Fatalf("Classify() = %T, want *PgError", got)
+			}
+			if pgErr.Constraint != tt.constraint {
+				t.Errorf("Constraint = %q, want %q", pgErr.Constraint, tt.constraint)
+			}
+			if got.Error() != tt.err.Error() {
+				t.Errorf("Error() = %q, want %q", got.Error(), tt.err.Error())
+			}
+
+			if r := IsRetryable(got); r != tt.retryable {
+				t.Errorf("IsRetryable() = %t, want %t", r, tt.retryable)
+			}
+		})
+	}
+}
+
+func TestClassifyUnchanged(t *testing.T) {
+	classified := Classify(&pgconn.PgError{Code: "23505"})
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "nil", err: nil},
+		{name: "not a postgres error", err: errors.New("boom")},
+		{name: "unknown state", err: &pgconn.PgError{Code: "42P01"}},
+		{name: "already classified", err: classified},
+		{name: "wrapped classified", err: fmt.Errorf("create: %w", classified)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.err {
+				t.Errorf("Classify() = %#v, want the error unchanged", got)
+			}
+			if IsRetryable(tt.err) {
+				t.Errorf("IsRetryable() = true, want false")
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package gorm
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"time"
@@ -54,6 +55,14 @@ func New(config *cfg.PostgresConfig) (*DB, error) {
 	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
 	sqlDB.SetConnMaxIdleTime(config.ConnMaxIdleTime)
 
+	if err := registerErrorClassifier(db); err != nil {
+		return nil, fmt.Errorf("failed to register error classifier: %w", err)
+	}
+
+	if err := db.Use(tracingPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+
 	if err := db.Exec("SET TIME ZONE 'UTC'").Error; err != nil {
 		return nil, fmt.Errorf("failed to set timezone to UTC: %w", err)
 	}
@@ -74,11 +83,11 @@ func Connect(config *cfg.PostgresConfig, maxRetries int, retryInterval time.Dura
 		db, err = New(config)
 		if err == nil {
 			// Test the connection
-			sqlDB, _ := db.DB.DB()
-			if err := sqlDB.Ping(); err == nil {
+			if err = db.Ping(context.Background()); err == nil {
 				log.Printf("Successfully connected to database on attempt %d", i+1)
 				return db, nil
 			}
+			_ = db.Close()
 		}
 
 		log.Printf("Failed to connect to database (attempt %d/%d): %v", i+1, maxRetries, err)
@@ -117,23 +126,14 @@ func (db *DB) Ping(ctx context.Context) error {
 	return nil
 }
 
-func (db *DB) Stats() map[string]interface{} {
+// Stats returns the connection pool statistics, zero when the pool is not a *sql.DB
+func (db *DB) Stats() sql.DBStats {
 	sqlDB, err := db.DB.DB()
 	if err != nil {
-		return map[string]interface{}{"error": err.Error()}
+		return sql.DBStats{}
 	}
 
-	stats := sqlDB.Stats()
-	return map[string]interface{}{
-		"max_open_connections": stats.MaxOpenConnections,
-		"open_connections":     stats.OpenConnections,
-		"in_use":               stats.InUse,
-		"idle":                 stats.Idle,
-		"wait_count":           stats.WaitCount,
-		"wait_duration":        stats.WaitDuration.String(),
-		"max_idle_closed":      stats.MaxIdleClosed,
-		"max_lifetime_closed":  stats.MaxLifetimeClosed,
-	}
+	return sqlDB.Stats()
 }
 
 func (db *DB) AutoMigrate(models ...interface{}) error {
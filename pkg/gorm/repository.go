@@ -26,6 +26,11 @@ func (r *Repository) WithContext(ctx context.Context) *gorm.DB {
 	return r.db.DB.WithContext(ctx)
 }
 
+// WithTransaction runs fn in a transaction that is retried on serialization failures and deadlocks
+func (r *Repository) WithTransaction(ctx context.Context, fn TransactionFunc) error {
+	return r.db.WithTransaction(ctx, fn)
+}
+
 // Create inserts a new record
 func (r *Repository) Create(ctx context.Context, model interface{}) error {
 	return r.WithContext(ctx).Create(model).Error
@@ -41,7 +46,7 @@ func (r *Repository) Update(ctx context.Context, model interface{}) error {
 	return r.WithContext(ctx).Save(model).Error
 }
 
-// Delete soft deletes a record
+// Delete soft deletes a record when the model has a DeletedAt field, otherwise it is removed
 func (r *Repository) Delete(ctx context.Context, model interface{}) error {
 	return r.WithContext(ctx).Delete(model).Error
 }
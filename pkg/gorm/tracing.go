@@ -0,0 +1,154 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	tracerName     = "github.com/vogiaan/ticketbottle-inventory/pkg/gorm"
+	tracingSpanKey = "tracing:span"
+)
+
+var tracer = otel.Tracer(tracerName)
+
+// tracingPlugin records a client span for every statement run through GORM. Row locking reads
+// are named after their lock, e.g. "SELECT ticket_class FOR UPDATE", so time spent waiting for
+// a lock stands out from plain queries.
+type tracingPlugin struct{}
+
+// tracedStatement is kept on the statement between the before and after callbacks
+type tracedStatement struct {
+	span   trace.Span
+	parent context.Context
+}
+
+func (tracingPlugin) Name() string {
+	return "ticketbottle:tracing"
+}
+
+func (p tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register("tracing:before_create", p.before("INSERT")); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register("tracing:after_create", p.after); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tracing:before_query", p.before("SELECT")); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register("tracing:after_query", p.after); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tracing:before_update", p.before("UPDATE")); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("tracing:after_update", p.after); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tracing:before_delete", p.before("DELETE")); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register("tracing:after_delete", p.after); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("tracing:before_row", p.before("SELECT")); err != nil {
+		return err
+	}
+	if err := cb.Row().After("gorm:row").Register("tracing:after_row", p.after); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("gorm:raw").Register("tracing:before_raw", p.before("RAW")); err != nil {
+		return err
+	}
+	return cb.Raw().After("gorm:raw").Register("tracing:after_raw", p.after)
+}
+
+func (p tracingPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		stmt := db.Statement
+		if stmt.Context == nil {
+			return
+		}
+
+		attrs := []attribute.KeyValue{
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(operation),
+		}
+
+		name := operation
+		if stmt.Table != "" {
+			name += " " + stmt.Table
+			attrs = append(attrs, semconv.DBCollectionName(stmt.Table))
+		}
+		if lock := lockStrength(stmt); lock != "" {
+			name += " FOR " + lock
+			attrs = append(attrs, attribute.String("db.lock", lock))
+		}
+
+		ctx, span := tracer.Start(stmt.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+		)
+		db.InstanceSet(tracingSpanKey, tracedStatement{span: span, parent: stmt.Context})
+		stmt.Context = ctx
+	}
+}
+
+func (p tracingPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	ts, ok := v.(tracedStatement)
+	if !ok {
+		return
+	}
+
+	// A chain reused for another statement starts from the caller's context again
+	db.Statement.Context = ts.parent
+
+	span := ts.span
+	defer span.End()
+
+	if !span.IsRecording() {
+		return
+	}
+
+	// Parameterised SQL only, the bound values may carry order codes
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+
+	if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// lockStrength returns the row lock of a locking read, e.g. "UPDATE" or "UPDATE SKIP LOCKED"
+func lockStrength(stmt *gorm.Statement) string {
+	c, ok := stmt.Clauses["FOR"]
+	if !ok {
+		return ""
+	}
+
+	locking, ok := c.Expression.(clause.Locking)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(locking.Strength + " " + locking.Options)
+}
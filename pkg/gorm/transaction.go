@@ -3,21 +3,64 @@ package gorm
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 )
 
 // TransactionFunc is a function that runs within a transaction
 type TransactionFunc func(*gorm.DB) error
 
-// WithTransaction executes a function within a database transaction
-func (db *DB) WithTransaction(ctx context.Context, fn TransactionFunc) error {
-	return db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := fn(tx); err != nil {
-			return fmt.Errorf("transaction failed: %w", err)
+// WithTransaction executes a function within a database transaction.
+// Serialization failures and deadlocks roll back and run fn again after a jittered backoff,
+// so fn must not keep state across attempts. The returned error is classified, see Classify.
+func (db *DB) WithTransaction(ctx context.Context, fn TransactionFunc) (err error) {
+	maxAttempts := max(db.config.TxMaxAttempts, 1)
+
+	ctx, span := tracer.Start(ctx, "db.transaction", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	for attempt := 1; ; attempt++ {
+		span.SetAttributes(attribute.Int("db.transaction.attempts", attempt))
+
+		err = Classify(db.DB.WithContext(ctx).Transaction(fn))
+		if err == nil || !IsRetryable(err) || attempt >= maxAttempts {
+			return err
+		}
+
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("db.transaction.attempt", attempt),
+			attribute.String("error", err.Error()),
+		))
+
+		select {
+		case <-time.After(db.retryDelay(attempt)):
+		case <-ctx.Done():
+			return err
 		}
-		return nil
-	})
+	}
+}
+
+// retryDelay returns a full jitter backoff, random between 0 and base * 2^(attempt-1) capped at the max delay
+func (db *DB) retryDelay(attempt int) time.Duration {
+	ceiling := db.config.TxRetryBaseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > db.config.TxRetryMaxDelay {
+		ceiling = db.config.TxRetryMaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling)
 }
 
 // BeginTransaction starts a new transaction
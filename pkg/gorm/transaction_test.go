@@ -0,0 +1,145 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	cfg "github.com/vogiaan/ticketbottle-inventory/config"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestWithTransactionRetries(t *testing.T) {
+	deadlock := &pgconn.PgError{Code: "40P01"}
+	unique := &pgconn.PgError{Code: "23505"}
+
+	tests := []struct {
+		name        string
+		maxAttempts int
+		// results is what fn returns on each attempt, fn is not called again after it runs out
+		results   []error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "success", maxAttempts: 3, results: []error{nil}, wantCalls: 1},
+		{name: "retried until success", maxAttempts: 3, results: []error{deadlock, deadlock, nil}, wantCalls: 3},
+		{name: "gives up after max attempts", maxAttempts: 3, results: []error{deadlock, deadlock, deadlock}, wantCalls: 3, wantErr: ErrDeadlockDetected},
+		{name: "not retryable", maxAttempts: 3, results: []error{unique}, wantCalls: 1, wantErr: ErrUniqueViolation},
+		{name: "retries disabled", maxAttempts: 1, results: []error{deadlock}, wantCalls: 1, wantErr: ErrDeadlockDetected},
+		{name: "zero max attempts runs once", maxAttempts: 0, results: []error{deadlock}, wantCalls: 1, wantErr: ErrDeadlockDetected},
+		{name: "serialization failure is retried", maxAttempts: 2, results: []error{&pgconn.PgError{Code: "40001"}, nil}, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t, &cfg.PostgresConfig{
+				TxMaxAttempts:    tt.maxAttempts,
+				TxRetryBaseDelay: time.Millisecond,
+				TxRetryMaxDelay:  5 * time.Millisecond,
+			})
+			for _, res := range tt.results {
+				mock.ExpectBegin()
+				if res == nil {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			calls := 0
+			err := db.WithTransaction(context.Background(), func(tx *gorm.DB) error {
+				calls++
+				return tt.results[calls-1]
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("fn called %d times, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("WithTransaction() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("WithTransaction() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWithTransactionStopsRetryingWhenCancelled(t *testing.T) {
+	db, mock := newMockDB(t, &cfg.PostgresConfig{
+		TxMaxAttempts:    5,
+		TxRetryBaseDelay: time.Hour,
+		TxRetryMaxDelay:  time.Hour,
+	})
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	err := db.WithTransaction(ctx, func(tx *gorm.DB) error {
+		calls++
+		return &pgconn.PgError{Code: "40P01"}
+	})
+
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+	if !errors.Is(err, ErrDeadlockDetected) {
+		t.Errorf("WithTransaction() error = %v, want the last attempt's error", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    time.Duration
+		max     time.Duration
+		attempt int
+		ceiling time.Duration
+	}{
+		{name: "first retry", base: 10 * time.Millisecond, max: time.Second, attempt: 1, ceiling: 10 * time.Millisecond},
+		{name: "doubles per attempt", base: 10 * time.Millisecond, max: time.Second, attempt: 4, ceiling: 80 * time.Millisecond},
+		{name: "capped", base: 10 * time.Millisecond, max: 50 * time.Millisecond, attempt: 10, ceiling: 50 * time.Millisecond},
+		{name: "overflow is capped", base: time.Second, max: 2 * time.Second, attempt: 70, ceiling: 2 * time.Second},
+		{name: "no delay configured", attempt: 3, ceiling: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &DB{config: &cfg.PostgresConfig{TxRetryBaseDelay: tt.base, TxRetryMaxDelay: tt.max}}
+
+			for range 100 {
+				d := db.retryDelay(tt.attempt)
+				if d < 0 || (tt.ceiling == 0 && d != 0) || (tt.ceiling > 0 && d >= tt.ceiling) {
+					t.Fatalf("retryDelay(%d) = %v, want within [0, %v)", tt.attempt, d, tt.ceiling)
+				}
+			}
+		})
+	}
+}
+
+func newMockDB(t *testing.T, config *cfg.PostgresConfig) (*DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New(): %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open(): %v", err)
+	}
+
+	return &DB{DB: db, config: config}, mock
+}
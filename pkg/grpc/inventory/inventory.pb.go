@@ -23,17 +23,25 @@ const (
 )
 
 type TicketClass struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	EventId       string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	PriceCents    int64                  `protobuf:"varint,4,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
-	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
-	Total         int32                  `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
-	StartSaleAt   string                 `protobuf:"bytes,7,opt,name=start_sale_at,json=startSaleAt,proto3" json:"start_sale_at,omitempty"`
-	EndSaleAt     string                 `protobuf:"bytes,8,opt,name=end_sale_at,json=endSaleAt,proto3" json:"end_sale_at,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId     string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	PriceCents  int64                  `protobuf:"varint,4,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	Currency    string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Total       int32                  `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	StartSaleAt string                 `protobuf:"bytes,7,opt,name=start_sale_at,json=startSaleAt,proto3" json:"start_sale_at,omitempty"`
+	EndSaleAt   string                 `protobuf:"bytes,8,opt,name=end_sale_at,json=endSaleAt,proto3" json:"end_sale_at,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Oversold    int32                  `protobuf:"varint,11,opt,name=oversold,proto3" json:"oversold,omitempty"`
+	// Incremented on every update, send it back as expected_version to avoid lost updates.
+	Version           int64  `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	Status            string `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	SalesPaused       bool   `protobuf:"varint,14,opt,name=sales_paused,json=salesPaused,proto3" json:"sales_paused,omitempty"`
+	SalesPausedReason string `protobuf:"bytes,15,opt,name=sales_paused_reason,json=salesPausedReason,proto3" json:"sales_paused_reason,omitempty"`
+	// Maximum quantity a single order may hold, 0 means no limit.
+	MaxPerOrder   int32 `protobuf:"varint,16,opt,name=max_per_order,json=maxPerOrder,proto3" json:"max_per_order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -138,6 +146,48 @@ func (x *TicketClass) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *TicketClass) GetOversold() int32 {
+	if x != nil {
+		return x.Oversold
+	}
+	return 0
+}
+
+func (x *TicketClass) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TicketClass) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TicketClass) GetSalesPaused() bool {
+	if x != nil {
+		return x.SalesPaused
+	}
+	return false
+}
+
+func (x *TicketClass) GetSalesPausedReason() string {
+	if x != nil {
+		return x.SalesPausedReason
+	}
+	return ""
+}
+
+func (x *TicketClass) GetMaxPerOrder() int32 {
+	if x != nil {
+		return x.MaxPerOrder
+	}
+	return 0
+}
+
 type CreateTicketClassRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
@@ -147,6 +197,7 @@ type CreateTicketClassRequest struct {
 	Total         int32                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
 	StartSaleAt   string                 `protobuf:"bytes,6,opt,name=start_sale_at,json=startSaleAt,proto3" json:"start_sale_at,omitempty"`
 	EndSaleAt     string                 `protobuf:"bytes,7,opt,name=end_sale_at,json=endSaleAt,proto3" json:"end_sale_at,omitempty"`
+	MaxPerOrder   int32                  `protobuf:"varint,8,opt,name=max_per_order,json=maxPerOrder,proto3" json:"max_per_order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -230,6 +281,13 @@ func (x *CreateTicketClassRequest) GetEndSaleAt() string {
 	return ""
 }
 
+func (x *CreateTicketClassRequest) GetMaxPerOrder() int32 {
+	if x != nil {
+		return x.MaxPerOrder
+	}
+	return 0
+}
+
 type CreateTicketClassResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TicketClass   *TicketClass           `protobuf:"bytes,1,opt,name=ticket_class,json=ticketClass,proto3" json:"ticket_class,omitempty"`
@@ -275,16 +333,21 @@ func (x *CreateTicketClassResponse) GetTicketClass() *TicketClass {
 }
 
 type UpdateTicketClassRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PriceCents    int64                  `protobuf:"varint,3,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
-	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
-	Total         int32                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
-	StartSaleAt   string                 `protobuf:"bytes,6,opt,name=start_sale_at,json=startSaleAt,proto3" json:"start_sale_at,omitempty"`
-	EndSaleAt     string                 `protobuf:"bytes,7,opt,name=end_sale_at,json=endSaleAt,proto3" json:"end_sale_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PriceCents  int64                  `protobuf:"varint,3,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	Currency    string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Total       int32                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	StartSaleAt string                 `protobuf:"bytes,6,opt,name=start_sale_at,json=startSaleAt,proto3" json:"start_sale_at,omitempty"`
+	EndSaleAt   string                 `protobuf:"bytes,7,opt,name=end_sale_at,json=endSaleAt,proto3" json:"end_sale_at,omitempty"`
+	// Accept a total below reserved + sold and record the difference as oversold.
+	Force bool `protobuf:"varint,8,opt,name=force,proto3" json:"force,omitempty"`
+	// When set, the update is rejected unless the stored version still matches.
+	ExpectedVersion int64 `protobuf:"varint,9,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	MaxPerOrder     int32 `protobuf:"varint,10,opt,name=max_per_order,json=maxPerOrder,proto3" json:"max_per_order,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateTicketClassRequest) Reset() {
@@ -366,6 +429,27 @@ func (x *UpdateTicketClassRequest) GetEndSaleAt() string {
 	return ""
 }
 
+func (x *UpdateTicketClassRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *UpdateTicketClassRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateTicketClassRequest) GetMaxPerOrder() int32 {
+	if x != nil {
+		return x.MaxPerOrder
+	}
+	return 0
+}
+
 type UpdateTicketClassResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TicketClass   *TicketClass           `protobuf:"bytes,1,opt,name=ticket_class,json=ticketClass,proto3" json:"ticket_class,omitempty"`
@@ -595,8 +679,10 @@ func (x *FindManyTicketClassResponse) GetTicketClasses() []*TicketClass {
 }
 
 type DeleteTicketClassRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Delete even when the class has active holds or sales. Active holds are cancelled.
+	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -638,28 +724,34 @@ func (x *DeleteTicketClassRequest) GetId() string {
 	return ""
 }
 
-type ReserveItem struct {
+func (x *DeleteTicketClassRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type RestoreTicketClassRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReserveItem) Reset() {
-	*x = ReserveItem{}
+func (x *RestoreTicketClassRequest) Reset() {
+	*x = RestoreTicketClassRequest{}
 	mi := &file_inventory_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReserveItem) String() string {
+func (x *RestoreTicketClassRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReserveItem) ProtoMessage() {}
+func (*RestoreTicketClassRequest) ProtoMessage() {}
 
-func (x *ReserveItem) ProtoReflect() protoreflect.Message {
+func (x *RestoreTicketClassRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -671,48 +763,39 @@ func (x *ReserveItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReserveItem.ProtoReflect.Descriptor instead.
-func (*ReserveItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreTicketClassRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTicketClassRequest) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ReserveItem) GetTicketClassId() string {
+func (x *RestoreTicketClassRequest) GetId() string {
 	if x != nil {
-		return x.TicketClassId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ReserveItem) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-type ReserveRequest struct {
+type RestoreTicketClassResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
-	Items         []*ReserveItem         `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	ExpiresAt     string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	TicketClass   *TicketClass           `protobuf:"bytes,1,opt,name=ticket_class,json=ticketClass,proto3" json:"ticket_class,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReserveRequest) Reset() {
-	*x = ReserveRequest{}
+func (x *RestoreTicketClassResponse) Reset() {
+	*x = RestoreTicketClassResponse{}
 	mi := &file_inventory_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReserveRequest) String() string {
+func (x *RestoreTicketClassResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReserveRequest) ProtoMessage() {}
+func (*RestoreTicketClassResponse) ProtoMessage() {}
 
-func (x *ReserveRequest) ProtoReflect() protoreflect.Message {
+func (x *RestoreTicketClassResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -724,53 +807,39 @@ func (x *ReserveRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReserveRequest.ProtoReflect.Descriptor instead.
-func (*ReserveRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreTicketClassResponse.ProtoReflect.Descriptor instead.
+func (*RestoreTicketClassResponse) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ReserveRequest) GetOrderCode() string {
-	if x != nil {
-		return x.OrderCode
-	}
-	return ""
-}
-
-func (x *ReserveRequest) GetItems() []*ReserveItem {
+func (x *RestoreTicketClassResponse) GetTicketClass() *TicketClass {
 	if x != nil {
-		return x.Items
+		return x.TicketClass
 	}
 	return nil
 }
 
-func (x *ReserveRequest) GetExpiresAt() string {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return ""
-}
-
-type ConfirmRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+type BatchCreateTicketClassesRequest struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	TicketClasses []*CreateTicketClassRequest `protobuf:"bytes,1,rep,name=ticket_classes,json=ticketClasses,proto3" json:"ticket_classes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfirmRequest) Reset() {
-	*x = ConfirmRequest{}
+func (x *BatchCreateTicketClassesRequest) Reset() {
+	*x = BatchCreateTicketClassesRequest{}
 	mi := &file_inventory_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfirmRequest) String() string {
+func (x *BatchCreateTicketClassesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfirmRequest) ProtoMessage() {}
+func (*BatchCreateTicketClassesRequest) ProtoMessage() {}
 
-func (x *ConfirmRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchCreateTicketClassesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -782,39 +851,39 @@ func (x *ConfirmRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfirmRequest.ProtoReflect.Descriptor instead.
-func (*ConfirmRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchCreateTicketClassesRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateTicketClassesRequest) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ConfirmRequest) GetOrderCode() string {
+func (x *BatchCreateTicketClassesRequest) GetTicketClasses() []*CreateTicketClassRequest {
 	if x != nil {
-		return x.OrderCode
+		return x.TicketClasses
 	}
-	return ""
+	return nil
 }
 
-type ReleaseRequest struct {
+type BatchCreateTicketClassesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	TicketClasses []*TicketClass         `protobuf:"bytes,1,rep,name=ticket_classes,json=ticketClasses,proto3" json:"ticket_classes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReleaseRequest) Reset() {
-	*x = ReleaseRequest{}
+func (x *BatchCreateTicketClassesResponse) Reset() {
+	*x = BatchCreateTicketClassesResponse{}
 	mi := &file_inventory_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseRequest) String() string {
+func (x *BatchCreateTicketClassesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseRequest) ProtoMessage() {}
+func (*BatchCreateTicketClassesResponse) ProtoMessage() {}
 
-func (x *ReleaseRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchCreateTicketClassesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -826,39 +895,42 @@ func (x *ReleaseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReleaseRequest.ProtoReflect.Descriptor instead.
-func (*ReleaseRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchCreateTicketClassesResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateTicketClassesResponse) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ReleaseRequest) GetOrderCode() string {
+func (x *BatchCreateTicketClassesResponse) GetTicketClasses() []*TicketClass {
 	if x != nil {
-		return x.OrderCode
+		return x.TicketClasses
 	}
-	return ""
+	return nil
 }
 
-type GetAvailabilityRequest struct {
+type CloneEventTicketClassesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	SourceEventId string                 `protobuf:"bytes,1,opt,name=source_event_id,json=sourceEventId,proto3" json:"source_event_id,omitempty"`
+	TargetEventId string                 `protobuf:"bytes,2,opt,name=target_event_id,json=targetEventId,proto3" json:"target_event_id,omitempty"`
+	// Added to the sale window of every cloned class, may be negative.
+	TimeShiftSeconds int64 `protobuf:"varint,3,opt,name=time_shift_seconds,json=timeShiftSeconds,proto3" json:"time_shift_seconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *GetAvailabilityRequest) Reset() {
-	*x = GetAvailabilityRequest{}
+func (x *CloneEventTicketClassesRequest) Reset() {
+	*x = CloneEventTicketClassesRequest{}
 	mi := &file_inventory_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAvailabilityRequest) String() string {
+func (x *CloneEventTicketClassesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailabilityRequest) ProtoMessage() {}
+func (*CloneEventTicketClassesRequest) ProtoMessage() {}
 
-func (x *GetAvailabilityRequest) ProtoReflect() protoreflect.Message {
+func (x *CloneEventTicketClassesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -870,39 +942,53 @@ func (x *GetAvailabilityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailabilityRequest.ProtoReflect.Descriptor instead.
-func (*GetAvailabilityRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CloneEventTicketClassesRequest.ProtoReflect.Descriptor instead.
+func (*CloneEventTicketClassesRequest) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetAvailabilityRequest) GetTicketClassId() string {
+func (x *CloneEventTicketClassesRequest) GetSourceEventId() string {
 	if x != nil {
-		return x.TicketClassId
+		return x.SourceEventId
 	}
 	return ""
 }
 
-type GetAvailabilityResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	AvailableQuantity int32                  `protobuf:"varint,1,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *CloneEventTicketClassesRequest) GetTargetEventId() string {
+	if x != nil {
+		return x.TargetEventId
+	}
+	return ""
 }
 
-func (x *GetAvailabilityResponse) Reset() {
-	*x = GetAvailabilityResponse{}
+func (x *CloneEventTicketClassesRequest) GetTimeShiftSeconds() int64 {
+	if x != nil {
+		return x.TimeShiftSeconds
+	}
+	return 0
+}
+
+type CloneEventTicketClassesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketClasses []*TicketClass         `protobuf:"bytes,1,rep,name=ticket_classes,json=ticketClasses,proto3" json:"ticket_classes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneEventTicketClassesResponse) Reset() {
+	*x = CloneEventTicketClassesResponse{}
 	mi := &file_inventory_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAvailabilityResponse) String() string {
+func (x *CloneEventTicketClassesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailabilityResponse) ProtoMessage() {}
+func (*CloneEventTicketClassesResponse) ProtoMessage() {}
 
-func (x *GetAvailabilityResponse) ProtoReflect() protoreflect.Message {
+func (x *CloneEventTicketClassesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -914,19 +1000,19 @@ func (x *GetAvailabilityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailabilityResponse.ProtoReflect.Descriptor instead.
-func (*GetAvailabilityResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CloneEventTicketClassesResponse.ProtoReflect.Descriptor instead.
+func (*CloneEventTicketClassesResponse) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GetAvailabilityResponse) GetAvailableQuantity() int32 {
+func (x *CloneEventTicketClassesResponse) GetTicketClasses() []*TicketClass {
 	if x != nil {
-		return x.AvailableQuantity
+		return x.TicketClasses
 	}
-	return 0
+	return nil
 }
 
-type CheckAvailabilityItem struct {
+type ReserveItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
@@ -934,20 +1020,20 @@ type CheckAvailabilityItem struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckAvailabilityItem) Reset() {
-	*x = CheckAvailabilityItem{}
+func (x *ReserveItem) Reset() {
+	*x = ReserveItem{}
 	mi := &file_inventory_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckAvailabilityItem) String() string {
+func (x *ReserveItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckAvailabilityItem) ProtoMessage() {}
+func (*ReserveItem) ProtoMessage() {}
 
-func (x *CheckAvailabilityItem) ProtoReflect() protoreflect.Message {
+func (x *ReserveItem) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -959,46 +1045,48 @@ func (x *CheckAvailabilityItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckAvailabilityItem.ProtoReflect.Descriptor instead.
-func (*CheckAvailabilityItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReserveItem.ProtoReflect.Descriptor instead.
+func (*ReserveItem) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CheckAvailabilityItem) GetTicketClassId() string {
+func (x *ReserveItem) GetTicketClassId() string {
 	if x != nil {
 		return x.TicketClassId
 	}
 	return ""
 }
 
-func (x *CheckAvailabilityItem) GetQuantity() int32 {
+func (x *ReserveItem) GetQuantity() int32 {
 	if x != nil {
 		return x.Quantity
 	}
 	return 0
 }
 
-type CheckAvailabilityRequest struct {
-	state         protoimpl.MessageState   `protogen:"open.v1"`
-	Items         []*CheckAvailabilityItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+type ReserveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Items         []*ReserveItem         `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckAvailabilityRequest) Reset() {
-	*x = CheckAvailabilityRequest{}
+func (x *ReserveRequest) Reset() {
+	*x = ReserveRequest{}
 	mi := &file_inventory_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckAvailabilityRequest) String() string {
+func (x *ReserveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckAvailabilityRequest) ProtoMessage() {}
+func (*ReserveRequest) ProtoMessage() {}
 
-func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+func (x *ReserveRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1010,40 +1098,1067 @@ func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
-func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReserveRequest.ProtoReflect.Descriptor instead.
+func (*ReserveRequest) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CheckAvailabilityRequest) GetItems() []*CheckAvailabilityItem {
+func (x *ReserveRequest) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetItems() []*ReserveItem {
 	if x != nil {
 		return x.Items
 	}
 	return nil
 }
 
-type CheckAvailabilityResponse struct {
+func (x *ReserveRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type ConfirmRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Accept        bool                   `protobuf:"varint,1,opt,name=accept,proto3" json:"accept,omitempty"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckAvailabilityResponse) Reset() {
-	*x = CheckAvailabilityResponse{}
+func (x *ConfirmRequest) Reset() {
+	*x = ConfirmRequest{}
+	mi := &file_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmRequest) ProtoMessage() {}
+
+func (x *ConfirmRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConfirmRequest) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+type ReleaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRequest) Reset() {
+	*x = ReleaseRequest{}
+	mi := &file_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRequest) ProtoMessage() {}
+
+func (x *ReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReleaseRequest) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+type GetAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailabilityRequest) Reset() {
+	*x = GetAvailabilityRequest{}
+	mi := &file_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailabilityRequest) ProtoMessage() {}
+
+func (x *GetAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetAvailabilityRequest) GetTicketClassId() string {
+	if x != nil {
+		return x.TicketClassId
+	}
+	return ""
+}
+
+type GetAvailabilityResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AvailableQuantity int32                  `protobuf:"varint,1,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetAvailabilityResponse) Reset() {
+	*x = GetAvailabilityResponse{}
+	mi := &file_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailabilityResponse) ProtoMessage() {}
+
+func (x *GetAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetAvailabilityResponse) GetAvailableQuantity() int32 {
+	if x != nil {
+		return x.AvailableQuantity
+	}
+	return 0
+}
+
+type GetEventAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventAvailabilityRequest) Reset() {
+	*x = GetEventAvailabilityRequest{}
+	mi := &file_inventory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventAvailabilityRequest) ProtoMessage() {}
+
+func (x *GetEventAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetEventAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetEventAvailabilityRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+// TicketClassAvailability is the availability of one ticket class.
+// sale_window is one of UPCOMING, OPEN or ENDED.
+type TicketClassAvailability struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId     string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Total             int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Reserved          int32                  `protobuf:"varint,4,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Sold              int32                  `protobuf:"varint,5,opt,name=sold,proto3" json:"sold,omitempty"`
+	AvailableQuantity int32                  `protobuf:"varint,6,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	SaleWindow        string                 `protobuf:"bytes,8,opt,name=sale_window,json=saleWindow,proto3" json:"sale_window,omitempty"`
+	StartSaleAt       string                 `protobuf:"bytes,9,opt,name=start_sale_at,json=startSaleAt,proto3" json:"start_sale_at,omitempty"`
+	EndSaleAt         string                 `protobuf:"bytes,10,opt,name=end_sale_at,json=endSaleAt,proto3" json:"end_sale_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TicketClassAvailability) Reset() {
+	*x = TicketClassAvailability{}
+	mi := &file_inventory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketClassAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketClassAvailability) ProtoMessage() {}
+
+func (x *TicketClassAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketClassAvailability.ProtoReflect.Descriptor instead.
+func (*TicketClassAvailability) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TicketClassAvailability) GetTicketClassId() string {
+	if x != nil {
+		return x.TicketClassId
+	}
+	return ""
+}
+
+func (x *TicketClassAvailability) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TicketClassAvailability) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TicketClassAvailability) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *TicketClassAvailability) GetSold() int32 {
+	if x != nil {
+		return x.Sold
+	}
+	return 0
+}
+
+func (x *TicketClassAvailability) GetAvailableQuantity() int32 {
+	if x != nil {
+		return x.AvailableQuantity
+	}
+	return 0
+}
+
+func (x *TicketClassAvailability) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TicketClassAvailability) GetSaleWindow() string {
+	if x != nil {
+		return x.SaleWindow
+	}
+	return ""
+}
+
+func (x *TicketClassAvailability) GetStartSaleAt() string {
+	if x != nil {
+		return x.StartSaleAt
+	}
+	return ""
+}
+
+func (x *TicketClassAvailability) GetEndSaleAt() string {
+	if x != nil {
+		return x.EndSaleAt
+	}
+	return ""
+}
+
+// GetEventAvailabilityResponse rolls the ticket classes of an event up to event level.
+// Counters sum every class, sale_window and on_sale only consider ACTIVE classes.
+type GetEventAvailabilityResponse struct {
+	state             protoimpl.MessageState     `protogen:"open.v1"`
+	EventId           string                     `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	TicketClasses     []*TicketClassAvailability `protobuf:"bytes,2,rep,name=ticket_classes,json=ticketClasses,proto3" json:"ticket_classes,omitempty"`
+	Total             int32                      `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Reserved          int32                      `protobuf:"varint,4,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Sold              int32                      `protobuf:"varint,5,opt,name=sold,proto3" json:"sold,omitempty"`
+	AvailableQuantity int32                      `protobuf:"varint,6,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	SaleWindow        string                     `protobuf:"bytes,7,opt,name=sale_window,json=saleWindow,proto3" json:"sale_window,omitempty"`
+	OnSale            bool                       `protobuf:"varint,8,opt,name=on_sale,json=onSale,proto3" json:"on_sale,omitempty"`
+	SalesPaused       bool                       `protobuf:"varint,9,opt,name=sales_paused,json=salesPaused,proto3" json:"sales_paused,omitempty"`
+	SalesPausedReason string                     `protobuf:"bytes,10,opt,name=sales_paused_reason,json=salesPausedReason,proto3" json:"sales_paused_reason,omitempty"`
+	CheckedAt         string                     `protobuf:"bytes,11,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetEventAvailabilityResponse) Reset() {
+	*x = GetEventAvailabilityResponse{}
+	mi := &file_inventory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventAvailabilityResponse) ProtoMessage() {}
+
+func (x *GetEventAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*GetEventAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetEventAvailabilityResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *GetEventAvailabilityResponse) GetTicketClasses() []*TicketClassAvailability {
+	if x != nil {
+		return x.TicketClasses
+	}
+	return nil
+}
+
+func (x *GetEventAvailabilityResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetEventAvailabilityResponse) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *GetEventAvailabilityResponse) GetSold() int32 {
+	if x != nil {
+		return x.Sold
+	}
+	return 0
+}
+
+func (x *GetEventAvailabilityResponse) GetAvailableQuantity() int32 {
+	if x != nil {
+		return x.AvailableQuantity
+	}
+	return 0
+}
+
+func (x *GetEventAvailabilityResponse) GetSaleWindow() string {
+	if x != nil {
+		return x.SaleWindow
+	}
+	return ""
+}
+
+func (x *GetEventAvailabilityResponse) GetOnSale() bool {
+	if x != nil {
+		return x.OnSale
+	}
+	return false
+}
+
+func (x *GetEventAvailabilityResponse) GetSalesPaused() bool {
+	if x != nil {
+		return x.SalesPaused
+	}
+	return false
+}
+
+func (x *GetEventAvailabilityResponse) GetSalesPausedReason() string {
+	if x != nil {
+		return x.SalesPausedReason
+	}
+	return ""
+}
+
+func (x *GetEventAvailabilityResponse) GetCheckedAt() string {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return ""
+}
+
+// WatchAvailabilityRequest selects the ticket classes to watch, either every class of an event
+// or an explicit list. Classes are resolved when the stream starts.
+type WatchAvailabilityRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EventId        string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	TicketClassIds []string               `protobuf:"bytes,2,rep,name=ticket_class_ids,json=ticketClassIds,proto3" json:"ticket_class_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WatchAvailabilityRequest) Reset() {
+	*x = WatchAvailabilityRequest{}
+	mi := &file_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAvailabilityRequest) ProtoMessage() {}
+
+func (x *WatchAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*WatchAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WatchAvailabilityRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WatchAvailabilityRequest) GetTicketClassIds() []string {
+	if x != nil {
+		return x.TicketClassIds
+	}
+	return nil
+}
+
+// AvailabilityUpdate is sent once per class when the stream starts and again every time
+// its counters change.
+type AvailabilityUpdate struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId     string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	EventId           string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Total             int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Reserved          int32                  `protobuf:"varint,4,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Sold              int32                  `protobuf:"varint,5,opt,name=sold,proto3" json:"sold,omitempty"`
+	AvailableQuantity int32                  `protobuf:"varint,6,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AvailabilityUpdate) Reset() {
+	*x = AvailabilityUpdate{}
+	mi := &file_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailabilityUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailabilityUpdate) ProtoMessage() {}
+
+func (x *AvailabilityUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailabilityUpdate.ProtoReflect.Descriptor instead.
+func (*AvailabilityUpdate) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AvailabilityUpdate) GetTicketClassId() string {
+	if x != nil {
+		return x.TicketClassId
+	}
+	return ""
+}
+
+func (x *AvailabilityUpdate) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *AvailabilityUpdate) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AvailabilityUpdate) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *AvailabilityUpdate) GetSold() int32 {
+	if x != nil {
+		return x.Sold
+	}
+	return 0
+}
+
+func (x *AvailabilityUpdate) GetAvailableQuantity() int32 {
+	if x != nil {
+		return x.AvailableQuantity
+	}
+	return 0
+}
+
+func (x *AvailabilityUpdate) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AvailabilityUpdate) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CheckAvailabilityItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityItem) Reset() {
+	*x = CheckAvailabilityItem{}
+	mi := &file_inventory_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityItem) ProtoMessage() {}
+
+func (x *CheckAvailabilityItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityItem.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityItem) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CheckAvailabilityItem) GetTicketClassId() string {
+	if x != nil {
+		return x.TicketClassId
+	}
+	return ""
+}
+
+func (x *CheckAvailabilityItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type CheckAvailabilityRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Items         []*CheckAvailabilityItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityRequest) Reset() {
+	*x = CheckAvailabilityRequest{}
+	mi := &file_inventory_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CheckAvailabilityRequest) GetItems() []*CheckAvailabilityItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// CheckAvailabilityItemResult reports one ticket class, duplicate ids in the request are combined.
+// reason is OK, NOT_FOUND, INACTIVE, OUTSIDE_SALE_WINDOW, SALES_PAUSED, OVER_PER_ORDER_LIMIT or INSUFFICIENT.
+type CheckAvailabilityItemResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId     string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	RequestedQuantity int32                  `protobuf:"varint,2,opt,name=requested_quantity,json=requestedQuantity,proto3" json:"requested_quantity,omitempty"`
+	AvailableQuantity int32                  `protobuf:"varint,3,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	Accepted          bool                   `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Reason            string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityItemResult) Reset() {
+	*x = CheckAvailabilityItemResult{}
+	mi := &file_inventory_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityItemResult) ProtoMessage() {}
+
+func (x *CheckAvailabilityItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityItemResult.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityItemResult) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CheckAvailabilityItemResult) GetTicketClassId() string {
+	if x != nil {
+		return x.TicketClassId
+	}
+	return ""
+}
+
+func (x *CheckAvailabilityItemResult) GetRequestedQuantity() int32 {
+	if x != nil {
+		return x.RequestedQuantity
+	}
+	return 0
+}
+
+func (x *CheckAvailabilityItemResult) GetAvailableQuantity() int32 {
+	if x != nil {
+		return x.AvailableQuantity
+	}
+	return 0
+}
+
+func (x *CheckAvailabilityItemResult) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *CheckAvailabilityItemResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CheckAvailabilityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when every item is accepted.
+	Accept        bool                           `protobuf:"varint,1,opt,name=accept,proto3" json:"accept,omitempty"`
+	Items         []*CheckAvailabilityItemResult `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityResponse) Reset() {
+	*x = CheckAvailabilityResponse{}
+	mi := &file_inventory_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CheckAvailabilityResponse) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+func (x *CheckAvailabilityResponse) GetItems() []*CheckAvailabilityItemResult {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CancelEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelEventRequest) Reset() {
+	*x = CancelEventRequest{}
+	mi := &file_inventory_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelEventRequest) ProtoMessage() {}
+
+func (x *CancelEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelEventRequest.ProtoReflect.Descriptor instead.
+func (*CancelEventRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CancelEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+// CancelEventResponse reports the outcome of a (possibly resumed) cancellation run.
+type CancelEventResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassesFrozen   int32                  `protobuf:"varint,1,opt,name=ticket_classes_frozen,json=ticketClassesFrozen,proto3" json:"ticket_classes_frozen,omitempty"`
+	ReservationsCancelled int32                  `protobuf:"varint,2,opt,name=reservations_cancelled,json=reservationsCancelled,proto3" json:"reservations_cancelled,omitempty"`
+	QuantityReleased      int32                  `protobuf:"varint,3,opt,name=quantity_released,json=quantityReleased,proto3" json:"quantity_released,omitempty"`
+	// Orders with confirmed tickets for the event, they need to be refunded.
+	RefundOrderCodes []string `protobuf:"bytes,4,rep,name=refund_order_codes,json=refundOrderCodes,proto3" json:"refund_order_codes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CancelEventResponse) Reset() {
+	*x = CancelEventResponse{}
+	mi := &file_inventory_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelEventResponse) ProtoMessage() {}
+
+func (x *CancelEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelEventResponse.ProtoReflect.Descriptor instead.
+func (*CancelEventResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CancelEventResponse) GetTicketClassesFrozen() int32 {
+	if x != nil {
+		return x.TicketClassesFrozen
+	}
+	return 0
+}
+
+func (x *CancelEventResponse) GetReservationsCancelled() int32 {
+	if x != nil {
+		return x.ReservationsCancelled
+	}
+	return 0
+}
+
+func (x *CancelEventResponse) GetQuantityReleased() int32 {
+	if x != nil {
+		return x.QuantityReleased
+	}
+	return 0
+}
+
+func (x *CancelEventResponse) GetRefundOrderCodes() []string {
+	if x != nil {
+		return x.RefundOrderCodes
+	}
+	return nil
+}
+
+type PauseSalesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseSalesRequest) Reset() {
+	*x = PauseSalesRequest{}
+	mi := &file_inventory_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseSalesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseSalesRequest) ProtoMessage() {}
+
+func (x *PauseSalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseSalesRequest.ProtoReflect.Descriptor instead.
+func (*PauseSalesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PauseSalesRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PauseSalesRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResumeSalesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeSalesRequest) Reset() {
+	*x = ResumeSalesRequest{}
+	mi := &file_inventory_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckAvailabilityResponse) String() string {
+func (x *ResumeSalesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckAvailabilityResponse) ProtoMessage() {}
+func (*ResumeSalesRequest) ProtoMessage() {}
 
-func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[18]
+func (x *ResumeSalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1054,23 +2169,362 @@ func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
-func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ResumeSalesRequest.ProtoReflect.Descriptor instead.
+func (*ResumeSalesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *CheckAvailabilityResponse) GetAccept() bool {
+func (x *ResumeSalesRequest) GetEventId() string {
 	if x != nil {
-		return x.Accept
+		return x.EventId
+	}
+	return ""
+}
+
+// WorkerStatus is the state and the last run of a background job on the replica that answered.
+type WorkerStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Leader-only workers run on a single replica at a time.
+	LeaderOnly bool `protobuf:"varint,2,opt,name=leader_only,json=leaderOnly,proto3" json:"leader_only,omitempty"`
+	// False on followers for leader-only workers.
+	Active         bool   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	Running        bool   `protobuf:"varint,4,opt,name=running,proto3" json:"running,omitempty"`
+	Paused         bool   `protobuf:"varint,5,opt,name=paused,proto3" json:"paused,omitempty"`
+	NextRunAt      string `protobuf:"bytes,6,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	LastRunAt      string `protobuf:"bytes,7,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`
+	LastDurationMs int64  `protobuf:"varint,8,opt,name=last_duration_ms,json=lastDurationMs,proto3" json:"last_duration_ms,omitempty"`
+	// OK or FAILED, empty before the first run.
+	LastResult string `protobuf:"bytes,9,opt,name=last_result,json=lastResult,proto3" json:"last_result,omitempty"`
+	LastError  string `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// Rows or events handled by the last run.
+	LastProcessed int32 `protobuf:"varint,11,opt,name=last_processed,json=lastProcessed,proto3" json:"last_processed,omitempty"`
+	Runs          int64 `protobuf:"varint,12,opt,name=runs,proto3" json:"runs,omitempty"`
+	Failures      int64 `protobuf:"varint,13,opt,name=failures,proto3" json:"failures,omitempty"`
+	// Runs that were skipped because the previous one was still running.
+	Skipped       int64 `protobuf:"varint,14,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerStatus) Reset() {
+	*x = WorkerStatus{}
+	mi := &file_inventory_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerStatus) ProtoMessage() {}
+
+func (x *WorkerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerStatus.ProtoReflect.Descriptor instead.
+func (*WorkerStatus) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WorkerStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkerStatus) GetLeaderOnly() bool {
+	if x != nil {
+		return x.LeaderOnly
+	}
+	return false
+}
+
+func (x *WorkerStatus) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *WorkerStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *WorkerStatus) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *WorkerStatus) GetNextRunAt() string {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return ""
+}
+
+func (x *WorkerStatus) GetLastRunAt() string {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return ""
+}
+
+func (x *WorkerStatus) GetLastDurationMs() int64 {
+	if x != nil {
+		return x.LastDurationMs
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetLastResult() string {
+	if x != nil {
+		return x.LastResult
+	}
+	return ""
+}
+
+func (x *WorkerStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WorkerStatus) GetLastProcessed() int32 {
+	if x != nil {
+		return x.LastProcessed
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetRuns() int64 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type ListWorkersResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Workers []*WorkerStatus        `protobuf:"bytes,1,rep,name=workers,proto3" json:"workers,omitempty"`
+	// Whether the replica that answered is the leader.
+	Leader        bool `protobuf:"varint,2,opt,name=leader,proto3" json:"leader,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkersResponse) Reset() {
+	*x = ListWorkersResponse{}
+	mi := &file_inventory_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkersResponse) ProtoMessage() {}
+
+func (x *ListWorkersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkersResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkersResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListWorkersResponse) GetWorkers() []*WorkerStatus {
+	if x != nil {
+		return x.Workers
+	}
+	return nil
+}
+
+func (x *ListWorkersResponse) GetLeader() bool {
+	if x != nil {
+		return x.Leader
 	}
 	return false
 }
 
+type TriggerWorkerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerWorkerRequest) Reset() {
+	*x = TriggerWorkerRequest{}
+	mi := &file_inventory_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerWorkerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerWorkerRequest) ProtoMessage() {}
+
+func (x *TriggerWorkerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerWorkerRequest.ProtoReflect.Descriptor instead.
+func (*TriggerWorkerRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *TriggerWorkerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PauseWorkerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseWorkerRequest) Reset() {
+	*x = PauseWorkerRequest{}
+	mi := &file_inventory_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseWorkerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseWorkerRequest) ProtoMessage() {}
+
+func (x *PauseWorkerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseWorkerRequest.ProtoReflect.Descriptor instead.
+func (*PauseWorkerRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PauseWorkerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ResumeWorkerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeWorkerRequest) Reset() {
+	*x = ResumeWorkerRequest{}
+	mi := &file_inventory_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeWorkerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeWorkerRequest) ProtoMessage() {}
+
+func (x *ResumeWorkerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeWorkerRequest.ProtoReflect.Descriptor instead.
+func (*ResumeWorkerRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ResumeWorkerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 var File_inventory_proto protoreflect.FileDescriptor
 
 const file_inventory_proto_rawDesc = "" +
 	"\n" +
-	"\x0finventory.proto\x12\x05event\x1a\x1bgoogle/protobuf/empty.proto\"\xa1\x02\n" +
+	"\x0finventory.proto\x12\x05event\x1a\x1bgoogle/protobuf/empty.proto\"\xe6\x03\n" +
 	"\vTicketClass\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x12\n" +
@@ -1085,7 +2539,13 @@ const file_inventory_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\tR\tupdatedAt\"\xe0\x01\n" +
+	" \x01(\tR\tupdatedAt\x12\x1a\n" +
+	"\boversold\x18\v \x01(\x05R\boversold\x12\x18\n" +
+	"\aversion\x18\f \x01(\x03R\aversion\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x12!\n" +
+	"\fsales_paused\x18\x0e \x01(\bR\vsalesPaused\x12.\n" +
+	"\x13sales_paused_reason\x18\x0f \x01(\tR\x11salesPausedReason\x12\"\n" +
+	"\rmax_per_order\x18\x10 \x01(\x05R\vmaxPerOrder\"\x84\x02\n" +
 	"\x18CreateTicketClassRequest\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
@@ -1094,9 +2554,10 @@ const file_inventory_proto_rawDesc = "" +
 	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x14\n" +
 	"\x05total\x18\x05 \x01(\x05R\x05total\x12\"\n" +
 	"\rstart_sale_at\x18\x06 \x01(\tR\vstartSaleAt\x12\x1e\n" +
-	"\vend_sale_at\x18\a \x01(\tR\tendSaleAt\"R\n" +
+	"\vend_sale_at\x18\a \x01(\tR\tendSaleAt\x12\"\n" +
+	"\rmax_per_order\x18\b \x01(\x05R\vmaxPerOrder\"R\n" +
 	"\x19CreateTicketClassResponse\x125\n" +
-	"\fticket_class\x18\x01 \x01(\v2\x12.event.TicketClassR\vticketClass\"\xd5\x01\n" +
+	"\fticket_class\x18\x01 \x01(\v2\x12.event.TicketClassR\vticketClass\"\xba\x02\n" +
 	"\x18UpdateTicketClassRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
@@ -1105,7 +2566,11 @@ const file_inventory_proto_rawDesc = "" +
 	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x14\n" +
 	"\x05total\x18\x05 \x01(\x05R\x05total\x12\"\n" +
 	"\rstart_sale_at\x18\x06 \x01(\tR\vstartSaleAt\x12\x1e\n" +
-	"\vend_sale_at\x18\a \x01(\tR\tendSaleAt\"R\n" +
+	"\vend_sale_at\x18\a \x01(\tR\tendSaleAt\x12\x14\n" +
+	"\x05force\x18\b \x01(\bR\x05force\x12)\n" +
+	"\x10expected_version\x18\t \x01(\x03R\x0fexpectedVersion\x12\"\n" +
+	"\rmax_per_order\x18\n" +
+	" \x01(\x05R\vmaxPerOrder\"R\n" +
 	"\x19UpdateTicketClassResponse\x125\n" +
 	"\fticket_class\x18\x01 \x01(\v2\x12.event.TicketClassR\vticketClass\"+\n" +
 	"\x19FindOneTicketClassRequest\x12\x0e\n" +
@@ -1116,9 +2581,24 @@ const file_inventory_proto_rawDesc = "" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x10\n" +
 	"\x03ids\x18\x02 \x03(\tR\x03ids\"X\n" +
 	"\x1bFindManyTicketClassResponse\x129\n" +
-	"\x0eticket_classes\x18\x01 \x03(\v2\x12.event.TicketClassR\rticketClasses\"*\n" +
+	"\x0eticket_classes\x18\x01 \x03(\v2\x12.event.TicketClassR\rticketClasses\"@\n" +
 	"\x18DeleteTicketClassRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"Q\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"+\n" +
+	"\x19RestoreTicketClassRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"S\n" +
+	"\x1aRestoreTicketClassResponse\x125\n" +
+	"\fticket_class\x18\x01 \x01(\v2\x12.event.TicketClassR\vticketClass\"i\n" +
+	"\x1fBatchCreateTicketClassesRequest\x12F\n" +
+	"\x0eticket_classes\x18\x01 \x03(\v2\x1f.event.CreateTicketClassRequestR\rticketClasses\"]\n" +
+	" BatchCreateTicketClassesResponse\x129\n" +
+	"\x0eticket_classes\x18\x01 \x03(\v2\x12.event.TicketClassR\rticketClasses\"\x9e\x01\n" +
+	"\x1eCloneEventTicketClassesRequest\x12&\n" +
+	"\x0fsource_event_id\x18\x01 \x01(\tR\rsourceEventId\x12&\n" +
+	"\x0ftarget_event_id\x18\x02 \x01(\tR\rtargetEventId\x12,\n" +
+	"\x12time_shift_seconds\x18\x03 \x01(\x03R\x10timeShiftSeconds\"\\\n" +
+	"\x1fCloneEventTicketClassesResponse\x129\n" +
+	"\x0eticket_classes\x18\x01 \x03(\v2\x12.event.TicketClassR\rticketClasses\"Q\n" +
 	"\vReserveItem\x12&\n" +
 	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\"x\n" +
@@ -1137,25 +2617,129 @@ const file_inventory_proto_rawDesc = "" +
 	"\x16GetAvailabilityRequest\x12&\n" +
 	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\"H\n" +
 	"\x17GetAvailabilityResponse\x12-\n" +
-	"\x12available_quantity\x18\x01 \x01(\x05R\x11availableQuantity\"[\n" +
+	"\x12available_quantity\x18\x01 \x01(\x05R\x11availableQuantity\"8\n" +
+	"\x1bGetEventAvailabilityRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\"\xc7\x02\n" +
+	"\x17TicketClassAvailability\x12&\n" +
+	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1a\n" +
+	"\breserved\x18\x04 \x01(\x05R\breserved\x12\x12\n" +
+	"\x04sold\x18\x05 \x01(\x05R\x04sold\x12-\n" +
+	"\x12available_quantity\x18\x06 \x01(\x05R\x11availableQuantity\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1f\n" +
+	"\vsale_window\x18\b \x01(\tR\n" +
+	"saleWindow\x12\"\n" +
+	"\rstart_sale_at\x18\t \x01(\tR\vstartSaleAt\x12\x1e\n" +
+	"\vend_sale_at\x18\n" +
+	" \x01(\tR\tendSaleAt\"\xa1\x03\n" +
+	"\x1cGetEventAvailabilityResponse\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12E\n" +
+	"\x0eticket_classes\x18\x02 \x03(\v2\x1e.event.TicketClassAvailabilityR\rticketClasses\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1a\n" +
+	"\breserved\x18\x04 \x01(\x05R\breserved\x12\x12\n" +
+	"\x04sold\x18\x05 \x01(\x05R\x04sold\x12-\n" +
+	"\x12available_quantity\x18\x06 \x01(\x05R\x11availableQuantity\x12\x1f\n" +
+	"\vsale_window\x18\a \x01(\tR\n" +
+	"saleWindow\x12\x17\n" +
+	"\aon_sale\x18\b \x01(\bR\x06onSale\x12!\n" +
+	"\fsales_paused\x18\t \x01(\bR\vsalesPaused\x12.\n" +
+	"\x13sales_paused_reason\x18\n" +
+	" \x01(\tR\x11salesPausedReason\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\v \x01(\tR\tcheckedAt\"_\n" +
+	"\x18WatchAvailabilityRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12(\n" +
+	"\x10ticket_class_ids\x18\x02 \x03(\tR\x0eticketClassIds\"\x83\x02\n" +
+	"\x12AvailabilityUpdate\x12&\n" +
+	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1a\n" +
+	"\breserved\x18\x04 \x01(\x05R\breserved\x12\x12\n" +
+	"\x04sold\x18\x05 \x01(\x05R\x04sold\x12-\n" +
+	"\x12available_quantity\x18\x06 \x01(\x05R\x11availableQuantity\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\"[\n" +
 	"\x15CheckAvailabilityItem\x12&\n" +
 	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\"N\n" +
 	"\x18CheckAvailabilityRequest\x122\n" +
-	"\x05items\x18\x01 \x03(\v2\x1c.event.CheckAvailabilityItemR\x05items\"3\n" +
+	"\x05items\x18\x01 \x03(\v2\x1c.event.CheckAvailabilityItemR\x05items\"\xd7\x01\n" +
+	"\x1bCheckAvailabilityItemResult\x12&\n" +
+	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12-\n" +
+	"\x12requested_quantity\x18\x02 \x01(\x05R\x11requestedQuantity\x12-\n" +
+	"\x12available_quantity\x18\x03 \x01(\x05R\x11availableQuantity\x12\x1a\n" +
+	"\baccepted\x18\x04 \x01(\bR\baccepted\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"m\n" +
 	"\x19CheckAvailabilityResponse\x12\x16\n" +
-	"\x06accept\x18\x01 \x01(\bR\x06accept2\xa1\x06\n" +
+	"\x06accept\x18\x01 \x01(\bR\x06accept\x128\n" +
+	"\x05items\x18\x02 \x03(\v2\".event.CheckAvailabilityItemResultR\x05items\"/\n" +
+	"\x12CancelEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\"\xdb\x01\n" +
+	"\x13CancelEventResponse\x122\n" +
+	"\x15ticket_classes_frozen\x18\x01 \x01(\x05R\x13ticketClassesFrozen\x125\n" +
+	"\x16reservations_cancelled\x18\x02 \x01(\x05R\x15reservationsCancelled\x12+\n" +
+	"\x11quantity_released\x18\x03 \x01(\x05R\x10quantityReleased\x12,\n" +
+	"\x12refund_order_codes\x18\x04 \x03(\tR\x10refundOrderCodes\"F\n" +
+	"\x11PauseSalesRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"/\n" +
+	"\x12ResumeSalesRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\"\xa8\x03\n" +
+	"\fWorkerStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vleader_only\x18\x02 \x01(\bR\n" +
+	"leaderOnly\x12\x16\n" +
+	"\x06active\x18\x03 \x01(\bR\x06active\x12\x18\n" +
+	"\arunning\x18\x04 \x01(\bR\arunning\x12\x16\n" +
+	"\x06paused\x18\x05 \x01(\bR\x06paused\x12\x1e\n" +
+	"\vnext_run_at\x18\x06 \x01(\tR\tnextRunAt\x12\x1e\n" +
+	"\vlast_run_at\x18\a \x01(\tR\tlastRunAt\x12(\n" +
+	"\x10last_duration_ms\x18\b \x01(\x03R\x0elastDurationMs\x12\x1f\n" +
+	"\vlast_result\x18\t \x01(\tR\n" +
+	"lastResult\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\n" +
+	" \x01(\tR\tlastError\x12%\n" +
+	"\x0elast_processed\x18\v \x01(\x05R\rlastProcessed\x12\x12\n" +
+	"\x04runs\x18\f \x01(\x03R\x04runs\x12\x1a\n" +
+	"\bfailures\x18\r \x01(\x03R\bfailures\x12\x18\n" +
+	"\askipped\x18\x0e \x01(\x03R\askipped\"\\\n" +
+	"\x13ListWorkersResponse\x12-\n" +
+	"\aworkers\x18\x01 \x03(\v2\x13.event.WorkerStatusR\aworkers\x12\x16\n" +
+	"\x06leader\x18\x02 \x01(\bR\x06leader\"*\n" +
+	"\x14TriggerWorkerRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"(\n" +
+	"\x12PauseWorkerRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\")\n" +
+	"\x13ResumeWorkerRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name2\xcf\v\n" +
 	"\x10InventoryService\x12V\n" +
 	"\x11CreateTicketClass\x12\x1f.event.CreateTicketClassRequest\x1a .event.CreateTicketClassResponse\x12V\n" +
 	"\x11UpdateTicketClass\x12\x1f.event.UpdateTicketClassRequest\x1a .event.UpdateTicketClassResponse\x12Y\n" +
 	"\x12FindOneTicketClass\x12 .event.FindOneTicketClassRequest\x1a!.event.FindOneTicketClassResponse\x12\\\n" +
 	"\x13FindManyTicketClass\x12!.event.FindManyTicketClassRequest\x1a\".event.FindManyTicketClassResponse\x12L\n" +
-	"\x11DeleteTicketClass\x12\x1f.event.DeleteTicketClassRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x11DeleteTicketClass\x12\x1f.event.DeleteTicketClassRequest\x1a\x16.google.protobuf.Empty\x12Y\n" +
+	"\x12RestoreTicketClass\x12 .event.RestoreTicketClassRequest\x1a!.event.RestoreTicketClassResponse\x12k\n" +
+	"\x18BatchCreateTicketClasses\x12&.event.BatchCreateTicketClassesRequest\x1a'.event.BatchCreateTicketClassesResponse\x12h\n" +
+	"\x17CloneEventTicketClasses\x12%.event.CloneEventTicketClassesRequest\x1a&.event.CloneEventTicketClassesResponse\x12V\n" +
 	"\x11CheckAvailability\x12\x1f.event.CheckAvailabilityRequest\x1a .event.CheckAvailabilityResponse\x12P\n" +
-	"\x0fGetAvailability\x12\x1d.event.GetAvailabilityRequest\x1a\x1e.event.GetAvailabilityResponse\x128\n" +
+	"\x0fGetAvailability\x12\x1d.event.GetAvailabilityRequest\x1a\x1e.event.GetAvailabilityResponse\x12_\n" +
+	"\x14GetEventAvailability\x12\".event.GetEventAvailabilityRequest\x1a#.event.GetEventAvailabilityResponse\x12Q\n" +
+	"\x11WatchAvailability\x12\x1f.event.WatchAvailabilityRequest\x1a\x19.event.AvailabilityUpdate0\x01\x128\n" +
 	"\aReserve\x12\x15.event.ReserveRequest\x1a\x16.google.protobuf.Empty\x128\n" +
 	"\aConfirm\x12\x15.event.ConfirmRequest\x1a\x16.google.protobuf.Empty\x128\n" +
-	"\aRelease\x12\x15.event.ReleaseRequest\x1a\x16.google.protobuf.EmptyB;Z9github.com/vogiaan1904/ticketbottle-proto/proto/inventoryb\x06proto3"
+	"\aRelease\x12\x15.event.ReleaseRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\vCancelEvent\x12\x19.event.CancelEventRequest\x1a\x1a.event.CancelEventResponse\x12>\n" +
+	"\n" +
+	"PauseSales\x12\x18.event.PauseSalesRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\vResumeSales\x12\x19.event.ResumeSalesRequest\x1a\x16.google.protobuf.Empty2\x9d\x02\n" +
+	"\x15InventoryAdminService\x12A\n" +
+	"\vListWorkers\x12\x16.google.protobuf.Empty\x1a\x1a.event.ListWorkersResponse\x12A\n" +
+	"\rTriggerWorker\x12\x1b.event.TriggerWorkerRequest\x1a\x13.event.WorkerStatus\x12=\n" +
+	"\vPauseWorker\x12\x19.event.PauseWorkerRequest\x1a\x13.event.WorkerStatus\x12?\n" +
+	"\fResumeWorker\x12\x1a.event.ResumeWorkerRequest\x1a\x13.event.WorkerStatusB;Z9github.com/vogiaan1904/ticketbottle-proto/proto/inventoryb\x06proto3"
 
 var (
 	file_inventory_proto_rawDescOnce sync.Once
@@ -1169,61 +2753,113 @@ func file_inventory_proto_rawDescGZIP() []byte {
 	return file_inventory_proto_rawDescData
 }
 
-var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_inventory_proto_goTypes = []any{
-	(*TicketClass)(nil),                 // 0: event.TicketClass
-	(*CreateTicketClassRequest)(nil),    // 1: event.CreateTicketClassRequest
-	(*CreateTicketClassResponse)(nil),   // 2: event.CreateTicketClassResponse
-	(*UpdateTicketClassRequest)(nil),    // 3: event.UpdateTicketClassRequest
-	(*UpdateTicketClassResponse)(nil),   // 4: event.UpdateTicketClassResponse
-	(*FindOneTicketClassRequest)(nil),   // 5: event.FindOneTicketClassRequest
-	(*FindOneTicketClassResponse)(nil),  // 6: event.FindOneTicketClassResponse
-	(*FindManyTicketClassRequest)(nil),  // 7: event.FindManyTicketClassRequest
-	(*FindManyTicketClassResponse)(nil), // 8: event.FindManyTicketClassResponse
-	(*DeleteTicketClassRequest)(nil),    // 9: event.DeleteTicketClassRequest
-	(*ReserveItem)(nil),                 // 10: event.ReserveItem
-	(*ReserveRequest)(nil),              // 11: event.ReserveRequest
-	(*ConfirmRequest)(nil),              // 12: event.ConfirmRequest
-	(*ReleaseRequest)(nil),              // 13: event.ReleaseRequest
-	(*GetAvailabilityRequest)(nil),      // 14: event.GetAvailabilityRequest
-	(*GetAvailabilityResponse)(nil),     // 15: event.GetAvailabilityResponse
-	(*CheckAvailabilityItem)(nil),       // 16: event.CheckAvailabilityItem
-	(*CheckAvailabilityRequest)(nil),    // 17: event.CheckAvailabilityRequest
-	(*CheckAvailabilityResponse)(nil),   // 18: event.CheckAvailabilityResponse
-	(*emptypb.Empty)(nil),               // 19: google.protobuf.Empty
+	(*TicketClass)(nil),                      // 0: event.TicketClass
+	(*CreateTicketClassRequest)(nil),         // 1: event.CreateTicketClassRequest
+	(*CreateTicketClassResponse)(nil),        // 2: event.CreateTicketClassResponse
+	(*UpdateTicketClassRequest)(nil),         // 3: event.UpdateTicketClassRequest
+	(*UpdateTicketClassResponse)(nil),        // 4: event.UpdateTicketClassResponse
+	(*FindOneTicketClassRequest)(nil),        // 5: event.FindOneTicketClassRequest
+	(*FindOneTicketClassResponse)(nil),       // 6: event.FindOneTicketClassResponse
+	(*FindManyTicketClassRequest)(nil),       // 7: event.FindManyTicketClassRequest
+	(*FindManyTicketClassResponse)(nil),      // 8: event.FindManyTicketClassResponse
+	(*DeleteTicketClassRequest)(nil),         // 9: event.DeleteTicketClassRequest
+	(*RestoreTicketClassRequest)(nil),        // 10: event.RestoreTicketClassRequest
+	(*RestoreTicketClassResponse)(nil),       // 11: event.RestoreTicketClassResponse
+	(*BatchCreateTicketClassesRequest)(nil),  // 12: event.BatchCreateTicketClassesRequest
+	(*BatchCreateTicketClassesResponse)(nil), // 13: event.BatchCreateTicketClassesResponse
+	(*CloneEventTicketClassesRequest)(nil),   // 14: event.CloneEventTicketClassesRequest
+	(*CloneEventTicketClassesResponse)(nil),  // 15: event.CloneEventTicketClassesResponse
+	(*ReserveItem)(nil),                      // 16: event.ReserveItem
+	(*ReserveRequest)(nil),                   // 17: event.ReserveRequest
+	(*ConfirmRequest)(nil),                   // 18: event.ConfirmRequest
+	(*ReleaseRequest)(nil),                   // 19: event.ReleaseRequest
+	(*GetAvailabilityRequest)(nil),           // 20: event.GetAvailabilityRequest
+	(*GetAvailabilityResponse)(nil),          // 21: event.GetAvailabilityResponse
+	(*GetEventAvailabilityRequest)(nil),      // 22: event.GetEventAvailabilityRequest
+	(*TicketClassAvailability)(nil),          // 23: event.TicketClassAvailability
+	(*GetEventAvailabilityResponse)(nil),     // 24: event.GetEventAvailabilityResponse
+	(*WatchAvailabilityRequest)(nil),         // 25: event.WatchAvailabilityRequest
+	(*AvailabilityUpdate)(nil),               // 26: event.AvailabilityUpdate
+	(*CheckAvailabilityItem)(nil),            // 27: event.CheckAvailabilityItem
+	(*CheckAvailabilityRequest)(nil),         // 28: event.CheckAvailabilityRequest
+	(*CheckAvailabilityItemResult)(nil),      // 29: event.CheckAvailabilityItemResult
+	(*CheckAvailabilityResponse)(nil),        // 30: event.CheckAvailabilityResponse
+	(*CancelEventRequest)(nil),               // 31: event.CancelEventRequest
+	(*CancelEventResponse)(nil),              // 32: event.CancelEventResponse
+	(*PauseSalesRequest)(nil),                // 33: event.PauseSalesRequest
+	(*ResumeSalesRequest)(nil),               // 34: event.ResumeSalesRequest
+	(*WorkerStatus)(nil),                     // 35: event.WorkerStatus
+	(*ListWorkersResponse)(nil),              // 36: event.ListWorkersResponse
+	(*TriggerWorkerRequest)(nil),             // 37: event.TriggerWorkerRequest
+	(*PauseWorkerRequest)(nil),               // 38: event.PauseWorkerRequest
+	(*ResumeWorkerRequest)(nil),              // 39: event.ResumeWorkerRequest
+	(*emptypb.Empty)(nil),                    // 40: google.protobuf.Empty
 }
 var file_inventory_proto_depIdxs = []int32{
 	0,  // 0: event.CreateTicketClassResponse.ticket_class:type_name -> event.TicketClass
 	0,  // 1: event.UpdateTicketClassResponse.ticket_class:type_name -> event.TicketClass
 	0,  // 2: event.FindOneTicketClassResponse.ticket_class:type_name -> event.TicketClass
 	0,  // 3: event.FindManyTicketClassResponse.ticket_classes:type_name -> event.TicketClass
-	10, // 4: event.ReserveRequest.items:type_name -> event.ReserveItem
-	16, // 5: event.CheckAvailabilityRequest.items:type_name -> event.CheckAvailabilityItem
-	1,  // 6: event.InventoryService.CreateTicketClass:input_type -> event.CreateTicketClassRequest
-	3,  // 7: event.InventoryService.UpdateTicketClass:input_type -> event.UpdateTicketClassRequest
-	5,  // 8: event.InventoryService.FindOneTicketClass:input_type -> event.FindOneTicketClassRequest
-	7,  // 9: event.InventoryService.FindManyTicketClass:input_type -> event.FindManyTicketClassRequest
-	9,  // 10: event.InventoryService.DeleteTicketClass:input_type -> event.DeleteTicketClassRequest
-	17, // 11: event.InventoryService.CheckAvailability:input_type -> event.CheckAvailabilityRequest
-	14, // 12: event.InventoryService.GetAvailability:input_type -> event.GetAvailabilityRequest
-	11, // 13: event.InventoryService.Reserve:input_type -> event.ReserveRequest
-	12, // 14: event.InventoryService.Confirm:input_type -> event.ConfirmRequest
-	13, // 15: event.InventoryService.Release:input_type -> event.ReleaseRequest
-	2,  // 16: event.InventoryService.CreateTicketClass:output_type -> event.CreateTicketClassResponse
-	4,  // 17: event.InventoryService.UpdateTicketClass:output_type -> event.UpdateTicketClassResponse
-	6,  // 18: event.InventoryService.FindOneTicketClass:output_type -> event.FindOneTicketClassResponse
-	8,  // 19: event.InventoryService.FindManyTicketClass:output_type -> event.FindManyTicketClassResponse
-	19, // 20: event.InventoryService.DeleteTicketClass:output_type -> google.protobuf.Empty
-	18, // 21: event.InventoryService.CheckAvailability:output_type -> event.CheckAvailabilityResponse
-	15, // 22: event.InventoryService.GetAvailability:output_type -> event.GetAvailabilityResponse
-	19, // 23: event.InventoryService.Reserve:output_type -> google.protobuf.Empty
-	19, // 24: event.InventoryService.Confirm:output_type -> google.protobuf.Empty
-	19, // 25: event.InventoryService.Release:output_type -> google.protobuf.Empty
-	16, // [16:26] is the sub-list for method output_type
-	6,  // [6:16] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	0,  // 4: event.RestoreTicketClassResponse.ticket_class:type_name -> event.TicketClass
+	1,  // 5: event.BatchCreateTicketClassesRequest.ticket_classes:type_name -> event.CreateTicketClassRequest
+	0,  // 6: event.BatchCreateTicketClassesResponse.ticket_classes:type_name -> event.TicketClass
+	0,  // 7: event.CloneEventTicketClassesResponse.ticket_classes:type_name -> event.TicketClass
+	16, // 8: event.ReserveRequest.items:type_name -> event.ReserveItem
+	23, // 9: event.GetEventAvailabilityResponse.ticket_classes:type_name -> event.TicketClassAvailability
+	27, // 10: event.CheckAvailabilityRequest.items:type_name -> event.CheckAvailabilityItem
+	29, // 11: event.CheckAvailabilityResponse.items:type_name -> event.CheckAvailabilityItemResult
+	35, // 12: event.ListWorkersResponse.workers:type_name -> event.WorkerStatus
+	1,  // 13: event.InventoryService.CreateTicketClass:input_type -> event.CreateTicketClassRequest
+	3,  // 14: event.InventoryService.UpdateTicketClass:input_type -> event.UpdateTicketClassRequest
+	5,  // 15: event.InventoryService.FindOneTicketClass:input_type -> event.FindOneTicketClassRequest
+	7,  // 16: event.InventoryService.FindManyTicketClass:input_type -> event.FindManyTicketClassRequest
+	9,  // 17: event.InventoryService.DeleteTicketClass:input_type -> event.DeleteTicketClassRequest
+	10, // 18: event.InventoryService.RestoreTicketClass:input_type -> event.RestoreTicketClassRequest
+	12, // 19: event.InventoryService.BatchCreateTicketClasses:input_type -> event.BatchCreateTicketClassesRequest
+	14, // 20: event.InventoryService.CloneEventTicketClasses:input_type -> event.CloneEventTicketClassesRequest
+	28, // 21: event.InventoryService.CheckAvailability:input_type -> event.CheckAvailabilityRequest
+	20, // 22: event.InventoryService.GetAvailability:input_type -> event.GetAvailabilityRequest
+	22, // 23: event.InventoryService.GetEventAvailability:input_type -> event.GetEventAvailabilityRequest
+	25, // 24: event.InventoryService.WatchAvailability:input_type -> event.WatchAvailabilityRequest
+	17, // 25: event.InventoryService.Reserve:input_type -> event.ReserveRequest
+	18, // 26: event.InventoryService.Confirm:input_type -> event.ConfirmRequest
+	19, // 27: event.InventoryService.Release:input_type -> event.ReleaseRequest
+	31, // 28: event.InventoryService.CancelEvent:input_type -> event.CancelEventRequest
+	33, // 29: event.InventoryService.PauseSales:input_type -> event.PauseSalesRequest
+	34, // 30: event.InventoryService.ResumeSales:input_type -> event.ResumeSalesRequest
+	40, // 31: event.InventoryAdminService.ListWorkers:input_type -> google.protobuf.Empty
+	37, // 32: event.InventoryAdminService.TriggerWorker:input_type -> event.TriggerWorkerRequest
+	38, // 33: event.InventoryAdminService.PauseWorker:input_type -> event.PauseWorkerRequest
+	39, // 34: event.InventoryAdminService.ResumeWorker:input_type -> event.ResumeWorkerRequest
+	2,  // 35: event.InventoryService.CreateTicketClass:output_type -> event.CreateTicketClassResponse
+	4,  // 36: event.InventoryService.UpdateTicketClass:output_type -> event.UpdateTicketClassResponse
+	6,  // 37: event.InventoryService.FindOneTicketClass:output_type -> event.FindOneTicketClassResponse
+	8,  // 38: event.InventoryService.FindManyTicketClass:output_type -> event.FindManyTicketClassResponse
+	40, // 39: event.InventoryService.DeleteTicketClass:output_type -> google.protobuf.Empty
+	11, // 40: event.InventoryService.RestoreTicketClass:output_type -> event.RestoreTicketClassResponse
+	13, // 41: event.InventoryService.BatchCreateTicketClasses:output_type -> event.BatchCreateTicketClassesResponse
+	15, // 42: event.InventoryService.CloneEventTicketClasses:output_type -> event.CloneEventTicketClassesResponse
+	30, // 43: event.InventoryService.CheckAvailability:output_type -> event.CheckAvailabilityResponse
+	21, // 44: event.InventoryService.GetAvailability:output_type -> event.GetAvailabilityResponse
+	24, // 45: event.InventoryService.GetEventAvailability:output_type -> event.GetEventAvailabilityResponse
+	26, // 46: event.InventoryService.WatchAvailability:output_type -> event.AvailabilityUpdate
+	40, // 47: event.InventoryService.Reserve:output_type -> google.protobuf.Empty
+	40, // 48: event.InventoryService.Confirm:output_type -> google.protobuf.Empty
+	40, // 49: event.InventoryService.Release:output_type -> google.protobuf.Empty
+	32, // 50: event.InventoryService.CancelEvent:output_type -> event.CancelEventResponse
+	40, // 51: event.InventoryService.PauseSales:output_type -> google.protobuf.Empty
+	40, // 52: event.InventoryService.ResumeSales:output_type -> google.protobuf.Empty
+	36, // 53: event.InventoryAdminService.ListWorkers:output_type -> event.ListWorkersResponse
+	35, // 54: event.InventoryAdminService.TriggerWorker:output_type -> event.WorkerStatus
+	35, // 55: event.InventoryAdminService.PauseWorker:output_type -> event.WorkerStatus
+	35, // 56: event.InventoryAdminService.ResumeWorker:output_type -> event.WorkerStatus
+	35, // [35:57] is the sub-list for method output_type
+	13, // [13:35] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_inventory_proto_init() }
@@ -1237,9 +2873,9 @@ func file_inventory_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   40,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_inventory_proto_goTypes,
 		DependencyIndexes: file_inventory_proto_depIdxs,
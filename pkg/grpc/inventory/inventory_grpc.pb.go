@@ -20,16 +20,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InventoryService_CreateTicketClass_FullMethodName   = "/event.InventoryService/CreateTicketClass"
-	InventoryService_UpdateTicketClass_FullMethodName   = "/event.InventoryService/UpdateTicketClass"
-	InventoryService_FindOneTicketClass_FullMethodName  = "/event.InventoryService/FindOneTicketClass"
-	InventoryService_FindManyTicketClass_FullMethodName = "/event.InventoryService/FindManyTicketClass"
-	InventoryService_DeleteTicketClass_FullMethodName   = "/event.InventoryService/DeleteTicketClass"
-	InventoryService_CheckAvailability_FullMethodName   = "/event.InventoryService/CheckAvailability"
-	InventoryService_GetAvailability_FullMethodName     = "/event.InventoryService/GetAvailability"
-	InventoryService_Reserve_FullMethodName             = "/event.InventoryService/Reserve"
-	InventoryService_Confirm_FullMethodName             = "/event.InventoryService/Confirm"
-	InventoryService_Release_FullMethodName             = "/event.InventoryService/Release"
+	InventoryService_CreateTicketClass_FullMethodName        = "/event.InventoryService/CreateTicketClass"
+	InventoryService_UpdateTicketClass_FullMethodName        = "/event.InventoryService/UpdateTicketClass"
+	InventoryService_FindOneTicketClass_FullMethodName       = "/event.InventoryService/FindOneTicketClass"
+	InventoryService_FindManyTicketClass_FullMethodName      = "/event.InventoryService/FindManyTicketClass"
+	InventoryService_DeleteTicketClass_FullMethodName        = "/event.InventoryService/DeleteTicketClass"
+	InventoryService_RestoreTicketClass_FullMethodName       = "/event.InventoryService/RestoreTicketClass"
+	InventoryService_BatchCreateTicketClasses_FullMethodName = "/event.InventoryService/BatchCreateTicketClasses"
+	InventoryService_CloneEventTicketClasses_FullMethodName  = "/event.InventoryService/CloneEventTicketClasses"
+	InventoryService_CheckAvailability_FullMethodName        = "/event.InventoryService/CheckAvailability"
+	InventoryService_GetAvailability_FullMethodName          = "/event.InventoryService/GetAvailability"
+	InventoryService_GetEventAvailability_FullMethodName     = "/event.InventoryService/GetEventAvailability"
+	InventoryService_WatchAvailability_FullMethodName        = "/event.InventoryService/WatchAvailability"
+	InventoryService_Reserve_FullMethodName                  = "/event.InventoryService/Reserve"
+	InventoryService_Confirm_FullMethodName                  = "/event.InventoryService/Confirm"
+	InventoryService_Release_FullMethodName                  = "/event.InventoryService/Release"
+	InventoryService_CancelEvent_FullMethodName              = "/event.InventoryService/CancelEvent"
+	InventoryService_PauseSales_FullMethodName               = "/event.InventoryService/PauseSales"
+	InventoryService_ResumeSales_FullMethodName              = "/event.InventoryService/ResumeSales"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -41,11 +49,19 @@ type InventoryServiceClient interface {
 	FindOneTicketClass(ctx context.Context, in *FindOneTicketClassRequest, opts ...grpc.CallOption) (*FindOneTicketClassResponse, error)
 	FindManyTicketClass(ctx context.Context, in *FindManyTicketClassRequest, opts ...grpc.CallOption) (*FindManyTicketClassResponse, error)
 	DeleteTicketClass(ctx context.Context, in *DeleteTicketClassRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreTicketClass(ctx context.Context, in *RestoreTicketClassRequest, opts ...grpc.CallOption) (*RestoreTicketClassResponse, error)
+	BatchCreateTicketClasses(ctx context.Context, in *BatchCreateTicketClassesRequest, opts ...grpc.CallOption) (*BatchCreateTicketClassesResponse, error)
+	CloneEventTicketClasses(ctx context.Context, in *CloneEventTicketClassesRequest, opts ...grpc.CallOption) (*CloneEventTicketClassesResponse, error)
 	CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error)
 	GetAvailability(ctx context.Context, in *GetAvailabilityRequest, opts ...grpc.CallOption) (*GetAvailabilityResponse, error)
+	GetEventAvailability(ctx context.Context, in *GetEventAvailabilityRequest, opts ...grpc.CallOption) (*GetEventAvailabilityResponse, error)
+	WatchAvailability(ctx context.Context, in *WatchAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AvailabilityUpdate], error)
 	Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Confirm(ctx context.Context, in *ConfirmRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CancelEvent(ctx context.Context, in *CancelEventRequest, opts ...grpc.CallOption) (*CancelEventResponse, error)
+	PauseSales(ctx context.Context, in *PauseSalesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ResumeSales(ctx context.Context, in *ResumeSalesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type inventoryServiceClient struct {
@@ -106,6 +122,36 @@ func (c *inventoryServiceClient) DeleteTicketClass(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *inventoryServiceClient) RestoreTicketClass(ctx context.Context, in *RestoreTicketClassRequest, opts ...grpc.CallOption) (*RestoreTicketClassResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreTicketClassResponse)
+	err := c.cc.Invoke(ctx, InventoryService_RestoreTicketClass_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) BatchCreateTicketClasses(ctx context.Context, in *BatchCreateTicketClassesRequest, opts ...grpc.CallOption) (*BatchCreateTicketClassesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCreateTicketClassesResponse)
+	err := c.cc.Invoke(ctx, InventoryService_BatchCreateTicketClasses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CloneEventTicketClasses(ctx context.Context, in *CloneEventTicketClassesRequest, opts ...grpc.CallOption) (*CloneEventTicketClassesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloneEventTicketClassesResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CloneEventTicketClasses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryServiceClient) CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CheckAvailabilityResponse)
@@ -126,6 +172,35 @@ func (c *inventoryServiceClient) GetAvailability(ctx context.Context, in *GetAva
 	return out, nil
 }
 
+func (c *inventoryServiceClient) GetEventAvailability(ctx context.Context, in *GetEventAvailabilityRequest, opts ...grpc.CallOption) (*GetEventAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEventAvailabilityResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetEventAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) WatchAvailability(ctx context.Context, in *WatchAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AvailabilityUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryService_ServiceDesc.Streams[0], InventoryService_WatchAvailability_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAvailabilityRequest, AvailabilityUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchAvailabilityClient = grpc.ServerStreamingClient[AvailabilityUpdate]
+
 func (c *inventoryServiceClient) Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -156,6 +231,36 @@ func (c *inventoryServiceClient) Release(ctx context.Context, in *ReleaseRequest
 	return out, nil
 }
 
+func (c *inventoryServiceClient) CancelEvent(ctx context.Context, in *CancelEventRequest, opts ...grpc.CallOption) (*CancelEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelEventResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CancelEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) PauseSales(ctx context.Context, in *PauseSalesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InventoryService_PauseSales_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ResumeSales(ctx context.Context, in *ResumeSalesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InventoryService_ResumeSales_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InventoryServiceServer is the server API for InventoryService service.
 // All implementations must embed UnimplementedInventoryServiceServer
 // for forward compatibility.
@@ -165,11 +270,19 @@ type InventoryServiceServer interface {
 	FindOneTicketClass(context.Context, *FindOneTicketClassRequest) (*FindOneTicketClassResponse, error)
 	FindManyTicketClass(context.Context, *FindManyTicketClassRequest) (*FindManyTicketClassResponse, error)
 	DeleteTicketClass(context.Context, *DeleteTicketClassRequest) (*emptypb.Empty, error)
+	RestoreTicketClass(context.Context, *RestoreTicketClassRequest) (*RestoreTicketClassResponse, error)
+	BatchCreateTicketClasses(context.Context, *BatchCreateTicketClassesRequest) (*BatchCreateTicketClassesResponse, error)
+	CloneEventTicketClasses(context.Context, *CloneEventTicketClassesRequest) (*CloneEventTicketClassesResponse, error)
 	CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error)
 	GetAvailability(context.Context, *GetAvailabilityRequest) (*GetAvailabilityResponse, error)
+	GetEventAvailability(context.Context, *GetEventAvailabilityRequest) (*GetEventAvailabilityResponse, error)
+	WatchAvailability(*WatchAvailabilityRequest, grpc.ServerStreamingServer[AvailabilityUpdate]) error
 	Reserve(context.Context, *ReserveRequest) (*emptypb.Empty, error)
 	Confirm(context.Context, *ConfirmRequest) (*emptypb.Empty, error)
 	Release(context.Context, *ReleaseRequest) (*emptypb.Empty, error)
+	CancelEvent(context.Context, *CancelEventRequest) (*CancelEventResponse, error)
+	PauseSales(context.Context, *PauseSalesRequest) (*emptypb.Empty, error)
+	ResumeSales(context.Context, *ResumeSalesRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedInventoryServiceServer()
 }
 
@@ -195,12 +308,27 @@ func (UnimplementedInventoryServiceServer) FindManyTicketClass(context.Context,
 func (UnimplementedInventoryServiceServer) DeleteTicketClass(context.Context, *DeleteTicketClassRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTicketClass not implemented")
 }
+func (UnimplementedInventoryServiceServer) RestoreTicketClass(context.Context, *RestoreTicketClassRequest) (*RestoreTicketClassResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTicketClass not implemented")
+}
+func (UnimplementedInventoryServiceServer) BatchCreateTicketClasses(context.Context, *BatchCreateTicketClassesRequest) (*BatchCreateTicketClassesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateTicketClasses not implemented")
+}
+func (UnimplementedInventoryServiceServer) CloneEventTicketClasses(context.Context, *CloneEventTicketClassesRequest) (*CloneEventTicketClassesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneEventTicketClasses not implemented")
+}
 func (UnimplementedInventoryServiceServer) CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckAvailability not implemented")
 }
 func (UnimplementedInventoryServiceServer) GetAvailability(context.Context, *GetAvailabilityRequest) (*GetAvailabilityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailability not implemented")
 }
+func (UnimplementedInventoryServiceServer) GetEventAvailability(context.Context, *GetEventAvailabilityRequest) (*GetEventAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventAvailability not implemented")
+}
+func (UnimplementedInventoryServiceServer) WatchAvailability(*WatchAvailabilityRequest, grpc.ServerStreamingServer[AvailabilityUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAvailability not implemented")
+}
 func (UnimplementedInventoryServiceServer) Reserve(context.Context, *ReserveRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reserve not implemented")
 }
@@ -210,6 +338,15 @@ func (UnimplementedInventoryServiceServer) Confirm(context.Context, *ConfirmRequ
 func (UnimplementedInventoryServiceServer) Release(context.Context, *ReleaseRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
 }
+func (UnimplementedInventoryServiceServer) CancelEvent(context.Context, *CancelEventRequest) (*CancelEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelEvent not implemented")
+}
+func (UnimplementedInventoryServiceServer) PauseSales(context.Context, *PauseSalesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseSales not implemented")
+}
+func (UnimplementedInventoryServiceServer) ResumeSales(context.Context, *ResumeSalesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSales not implemented")
+}
 func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
 func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
 
@@ -321,6 +458,60 @@ func _InventoryService_DeleteTicketClass_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_RestoreTicketClass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTicketClassRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RestoreTicketClass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RestoreTicketClass_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RestoreTicketClass(ctx, req.(*RestoreTicketClassRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_BatchCreateTicketClasses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateTicketClassesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).BatchCreateTicketClasses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_BatchCreateTicketClasses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).BatchCreateTicketClasses(ctx, req.(*BatchCreateTicketClassesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CloneEventTicketClasses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneEventTicketClassesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CloneEventTicketClasses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CloneEventTicketClasses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CloneEventTicketClasses(ctx, req.(*CloneEventTicketClassesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InventoryService_CheckAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckAvailabilityRequest)
 	if err := dec(in); err != nil {
@@ -357,6 +548,35 @@ func _InventoryService_GetAvailability_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_GetEventAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetEventAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetEventAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetEventAvailability(ctx, req.(*GetEventAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_WatchAvailability_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAvailabilityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServiceServer).WatchAvailability(m, &grpc.GenericServerStream[WatchAvailabilityRequest, AvailabilityUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchAvailabilityServer = grpc.ServerStreamingServer[AvailabilityUpdate]
+
 func _InventoryService_Reserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReserveRequest)
 	if err := dec(in); err != nil {
@@ -411,6 +631,60 @@ func _InventoryService_Release_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_CancelEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelEvent(ctx, req.(*CancelEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_PauseSales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseSalesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).PauseSales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_PauseSales_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).PauseSales(ctx, req.(*PauseSalesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ResumeSales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeSalesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ResumeSales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ResumeSales_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ResumeSales(ctx, req.(*ResumeSalesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InventoryService_ServiceDesc is the grpc.ServiceDesc for InventoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -438,6 +712,18 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTicketClass",
 			Handler:    _InventoryService_DeleteTicketClass_Handler,
 		},
+		{
+			MethodName: "RestoreTicketClass",
+			Handler:    _InventoryService_RestoreTicketClass_Handler,
+		},
+		{
+			MethodName: "BatchCreateTicketClasses",
+			Handler:    _InventoryService_BatchCreateTicketClasses_Handler,
+		},
+		{
+			MethodName: "CloneEventTicketClasses",
+			Handler:    _InventoryService_CloneEventTicketClasses_Handler,
+		},
 		{
 			MethodName: "CheckAvailability",
 			Handler:    _InventoryService_CheckAvailability_Handler,
@@ -446,6 +732,10 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAvailability",
 			Handler:    _InventoryService_GetAvailability_Handler,
 		},
+		{
+			MethodName: "GetEventAvailability",
+			Handler:    _InventoryService_GetEventAvailability_Handler,
+		},
 		{
 			MethodName: "Reserve",
 			Handler:    _InventoryService_Reserve_Handler,
@@ -458,6 +748,244 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Release",
 			Handler:    _InventoryService_Release_Handler,
 		},
+		{
+			MethodName: "CancelEvent",
+			Handler:    _InventoryService_CancelEvent_Handler,
+		},
+		{
+			MethodName: "PauseSales",
+			Handler:    _InventoryService_PauseSales_Handler,
+		},
+		{
+			MethodName: "ResumeSales",
+			Handler:    _InventoryService_ResumeSales_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAvailability",
+			Handler:       _InventoryService_WatchAvailability_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "inventory.proto",
+}
+
+const (
+	InventoryAdminService_ListWorkers_FullMethodName   = "/event.InventoryAdminService/ListWorkers"
+	InventoryAdminService_TriggerWorker_FullMethodName = "/event.InventoryAdminService/TriggerWorker"
+	InventoryAdminService_PauseWorker_FullMethodName   = "/event.InventoryAdminService/PauseWorker"
+	InventoryAdminService_ResumeWorker_FullMethodName  = "/event.InventoryAdminService/ResumeWorker"
+)
+
+// InventoryAdminServiceClient is the client API for InventoryAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// InventoryAdminService is for operators, it inspects and controls the background jobs of one replica.
+type InventoryAdminServiceClient interface {
+	ListWorkers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListWorkersResponse, error)
+	TriggerWorker(ctx context.Context, in *TriggerWorkerRequest, opts ...grpc.CallOption) (*WorkerStatus, error)
+	PauseWorker(ctx context.Context, in *PauseWorkerRequest, opts ...grpc.CallOption) (*WorkerStatus, error)
+	ResumeWorker(ctx context.Context, in *ResumeWorkerRequest, opts ...grpc.CallOption) (*WorkerStatus, error)
+}
+
+type inventoryAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInventoryAdminServiceClient(cc grpc.ClientConnInterface) InventoryAdminServiceClient {
+	return &inventoryAdminServiceClient{cc}
+}
+
+func (c *inventoryAdminServiceClient) ListWorkers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListWorkersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkersResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_ListWorkers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) TriggerWorker(ctx context.Context, in *TriggerWorkerRequest, opts ...grpc.CallOption) (*WorkerStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerStatus)
+	err := c.cc.Invoke(ctx, InventoryAdminService_TriggerWorker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) PauseWorker(ctx context.Context, in *PauseWorkerRequest, opts ...grpc.CallOption) (*WorkerStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerStatus)
+	err := c.cc.Invoke(ctx, InventoryAdminService_PauseWorker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) ResumeWorker(ctx context.Context, in *ResumeWorkerRequest, opts ...grpc.CallOption) (*WorkerStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerStatus)
+	err := c.cc.Invoke(ctx, InventoryAdminService_ResumeWorker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InventoryAdminServiceServer is the server API for InventoryAdminService service.
+// All implementations must embed UnimplementedInventoryAdminServiceServer
+// for forward compatibility.
+//
+// InventoryAdminService is for operators, it inspects and controls the background jobs of one replica.
+type InventoryAdminServiceServer interface {
+	ListWorkers(context.Context, *emptypb.Empty) (*ListWorkersResponse, error)
+	TriggerWorker(context.Context, *TriggerWorkerRequest) (*WorkerStatus, error)
+	PauseWorker(context.Context, *PauseWorkerRequest) (*WorkerStatus, error)
+	ResumeWorker(context.Context, *ResumeWorkerRequest) (*WorkerStatus, error)
+	mustEmbedUnimplementedInventoryAdminServiceServer()
+}
+
+// UnimplementedInventoryAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInventoryAdminServiceServer struct{}
+
+func (UnimplementedInventoryAdminServiceServer) ListWorkers(context.Context, *emptypb.Empty) (*ListWorkersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkers not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) TriggerWorker(context.Context, *TriggerWorkerRequest) (*WorkerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerWorker not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) PauseWorker(context.Context, *PauseWorkerRequest) (*WorkerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseWorker not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) ResumeWorker(context.Context, *ResumeWorkerRequest) (*WorkerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeWorker not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) mustEmbedUnimplementedInventoryAdminServiceServer() {}
+func (UnimplementedInventoryAdminServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeInventoryAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InventoryAdminServiceServer will
+// result in compilation errors.
+type UnsafeInventoryAdminServiceServer interface {
+	mustEmbedUnimplementedInventoryAdminServiceServer()
+}
+
+func RegisterInventoryAdminServiceServer(s grpc.ServiceRegistrar, srv InventoryAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedInventoryAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InventoryAdminService_ServiceDesc, srv)
+}
+
+func _InventoryAdminService_ListWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).ListWorkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_ListWorkers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).ListWorkers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_TriggerWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerWorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).TriggerWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_TriggerWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).TriggerWorker(ctx, req.(*TriggerWorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_PauseWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseWorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).PauseWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_PauseWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).PauseWorker(ctx, req.(*PauseWorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_ResumeWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeWorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).ResumeWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_ResumeWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).ResumeWorker(ctx, req.(*ResumeWorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InventoryAdminService_ServiceDesc is the grpc.ServiceDesc for InventoryAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InventoryAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "event.InventoryAdminService",
+	HandlerType: (*InventoryAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListWorkers",
+			Handler:    _InventoryAdminService_ListWorkers_Handler,
+		},
+		{
+			MethodName: "TriggerWorker",
+			Handler:    _InventoryAdminService_TriggerWorker_Handler,
+		},
+		{
+			MethodName: "PauseWorker",
+			Handler:    _InventoryAdminService_PauseWorker_Handler,
+		},
+		{
+			MethodName: "ResumeWorker",
+			Handler:    _InventoryAdminService_ResumeWorker_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inventory.proto",
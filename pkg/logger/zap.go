@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -113,11 +114,17 @@ func (l *zapLogger) ctx(ctx context.Context) *zap.SugaredLogger {
 	if ctx == nil {
 		panic("nil context passed to Logger")
 	}
-	if logger, _ := ctx.Value(loggerKey{}).(*zap.SugaredLogger); logger != nil {
-		return logger
+	logger := l.sugarLogger
+	if ctxLogger, _ := ctx.Value(loggerKey{}).(*zap.SugaredLogger); ctxLogger != nil {
+		logger = ctxLogger
 	}
 
-	return l.sugarLogger
+	// Lets log lines be matched with their trace
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		return logger.With("trace_id", sc.TraceID().String())
+	}
+
+	return logger
 }
 
 func (l *zapLogger) Debug(ctx context.Context, args ...any) {
@@ -0,0 +1,210 @@
+package migrate
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestNew(t *testing.T) {
+	file := func(s string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(s)} }
+
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr string
+	}{
+		{
+			name: "empty",
+			fsys: fstest.MapFS{},
+			want: []Migration{},
+		},
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"0010_tenth.up.sql":    file("up 10"),
+				"0010_tenth.down.sql":  file("down 10"),
+				"0002_second.up.sql":   file("up 2"),
+				"0002_second.down.sql": file("down 2"),
+				"0001_init.down.sql":   file("down 1"),
+				"0001_init.up.sql":     file("up 1"),
+			},
+			want: []Migration{
+				{Version: 1, Name: "init", Up: "up 1", Down: "down 1"},
+				{Version: 2, Name: "second", Up: "up 2", Down: "down 2"},
+				{Version: 10, Name: "tenth", Up: "up 10", Down: "down 10"},
+			},
+		},
+		{
+			name: "versions compare as numbers",
+			fsys: fstest.MapFS{
+				"9_nine.up.sql":      file("up 9"),
+				"9_nine.down.sql":    file("down 9"),
+				"0100_late.up.sql":   file("up 100"),
+				"0100_late.down.sql": file("down 100"),
+			},
+			want: []Migration{
+				{Version: 9, Name: "nine", Up: "up 9", Down: "down 9"},
+				{Version: 100, Name: "late", Up: "up 100", Down: "down 100"},
+			},
+		},
+		{
+			name: "other files and directories are ignored",
+			fsys: fstest.MapFS{
+				"0001_init.up.sql":          file("up 1"),
+				"0001_init.down.sql":        file("down 1"),
+				"README.md":                 file("docs"),
+				"embed.go":                  file("package migrations"),
+				"archive/0002_old.up.sql":   file("up 2"),
+				"archive/0002_old.down.sql": file("down 2"),
+			},
+			want: []Migration{
+				{Version: 1, Name: "init", Up: "up 1", Down: "down 1"},
+			},
+		},
+		{
+			name:    "invalid file name",
+			fsys:    fstest.MapFS{"init.up.sql": file("up")},
+			wantErr: "invalid migration file name: init.up.sql",
+		},
+		{
+			name:    "upper case name",
+			fsys:    fstest.MapFS{"0001_Init.up.sql": file("up")},
+			wantErr: "invalid migration file name: 0001_Init.up.sql",
+		},
+		{
+			name:    "missing down file",
+			fsys:    fstest.MapFS{"0001_init.up.sql": file("up")},
+			wantErr: "migration 1_init needs both an up and a down file",
+		},
+		{
+			name:    "missing up file",
+			fsys:    fstest.MapFS{"0001_init.down.sql": file("down")},
+			wantErr: "migration 1_init needs both an up and a down file",
+		},
+		{
+			name:    "empty up file",
+			fsys:    fstest.MapFS{"0001_init.up.sql": file(""), "0001_init.down.sql": file("down")},
+			wantErr: "migration 1_init needs both an up and a down file",
+		},
+		{
+			name: "names differ between up and down",
+			fsys: fstest.MapFS{
+				"0001_init.up.sql":    file("up"),
+				"0001_setup.down.sql": file("down"),
+			},
+			wantErr: "migration 1 has files with different names",
+		},
+		{
+			name:    "version overflows",
+			fsys:    fstest.MapFS{"99999999999999999999_big.up.sql": file("up")},
+			wantErr: "invalid migration version: 99999999999999999999_big.up.sql",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(nil, tt.fsys)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if !slices.Equal(m.migrations, tt.want) {
+				t.Errorf("migrations = %+v, want %+v", m.migrations, tt.want)
+			}
+
+			wantLatest := int64(0)
+			if len(tt.want) > 0 {
+				wantLatest = tt.want[len(tt.want)-1].Version
+			}
+			if got := m.Latest(); got != wantLatest {
+				t.Errorf("Latest() = %d, want %d", got, wantLatest)
+			}
+		})
+	}
+}
+
+func TestStatusReadsWithoutLock(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_init.up.sql":     {Data: []byte("up 1")},
+		"0001_init.down.sql":   {Data: []byte("down 1")},
+		"0002_second.up.sql":   {Data: []byte("up 2")},
+		"0002_second.down.sql": {Data: []byte("down 2")},
+	}
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		// tableExists is false on a database that was never migrated
+		tableExists bool
+		want        []bool
+	}{
+		{name: "never migrated", tableExists: false, want: []bool{false, false}},
+		{name: "partly migrated", tableExists: true, want: []bool{true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New(): %v", err)
+			}
+			t.Cleanup(func() { db.Close() })
+
+			m, err := New(db, fsys)
+			if err != nil {
+				t.Fatalf("New(): %v", err)
+			}
+
+			// Any advisory lock or CREATE TABLE would be an unexpected statement
+			mock.ExpectQuery(`SELECT to_regclass\('schema_migrations'\) IS NOT NULL`).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.tableExists))
+			if tt.tableExists {
+				mock.ExpectQuery(`SELECT version, applied_at FROM schema_migrations`).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, at))
+			}
+
+			sts, err := m.Status(context.Background())
+			if err != nil {
+				t.Fatalf("Status() error = %v", err)
+			}
+
+			got := make([]bool, len(sts))
+			for i, st := range sts {
+				got[i] = st.Applied
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("applied = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDownRejectsInvalidSteps(t *testing.T) {
+	m, err := New(nil, fstest.MapFS{})
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+
+	for _, steps := range []int{0, -1} {
+		if _, err := m.Down(context.Background(), steps); err == nil {
+			t.Errorf("Down(%d) error = nil, want an error", steps)
+		}
+	}
+}
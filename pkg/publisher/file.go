@@ -0,0 +1,54 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FilePublisher appends messages to a file as JSON lines, it is meant for local runs
+type FilePublisher struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+type fileRecord struct {
+	Topic   string            `json:"topic"`
+	Key     string            `json:"key"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Value   []byte            `json:"value"`
+}
+
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open publisher file: %w", err)
+	}
+
+	return &FilePublisher{f: f}, nil
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, msgs ...Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	enc := json.NewEncoder(p.f)
+	for _, msg := range msgs {
+		if err := enc.Encode(fileRecord{
+			Topic:   msg.Topic,
+			Key:     msg.Key,
+			Headers: msg.Headers,
+			Value:   msg.Value,
+		}); err != nil {
+			return fmt.Errorf("failed to write message: %w", err)
+		}
+	}
+
+	return p.f.Sync()
+}
+
+func (p *FilePublisher) Close() error {
+	return p.f.Close()
+}
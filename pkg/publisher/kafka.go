@@ -0,0 +1,56 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaPublisher writes messages to Kafka. Keys are hashed to partitions,
+// so messages with the same key keep their relative order.
+type KafkaPublisher struct {
+	w *kafka.Writer
+}
+
+func NewKafkaPublisher(brokers []string) *KafkaPublisher {
+	return &KafkaPublisher{
+		w: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, msgs ...Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	kMsgs := make([]kafka.Message, len(msgs))
+	for i, msg := range msgs {
+		headers := make([]kafka.Header, 0, len(msg.Headers))
+		for k, v := range msg.Headers {
+			headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+
+		kMsgs[i] = kafka.Message{
+			Topic:   msg.Topic,
+			Key:     []byte(msg.Key),
+			Value:   msg.Value,
+			Headers: headers,
+		}
+	}
+
+	if err := p.w.WriteMessages(ctx, kMsgs...); err != nil {
+		return fmt.Errorf("failed to write kafka messages: %w", err)
+	}
+
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.w.Close()
+}
@@ -0,0 +1,38 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPublisher keeps published messages in memory, it is meant for tests and local runs
+type MemoryPublisher struct {
+	mu   sync.Mutex
+	msgs []Message
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, msgs ...Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.msgs = append(p.msgs, msgs...)
+	return nil
+}
+
+// Messages returns a copy of everything published so far
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	msgs := make([]Message, len(p.msgs))
+	copy(msgs, p.msgs)
+	return msgs
+}
+
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+
+	cfg "github.com/vogiaan/ticketbottle-inventory/config"
+)
+
+// Message is a single record handed to a publisher backend
+type Message struct {
+	Topic   string
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+// Publisher delivers messages to a broker.
+// Messages sharing a key must be delivered in the order they are passed in.
+type Publisher interface {
+	Publish(ctx context.Context, msgs ...Message) error
+	Close() error
+}
+
+// New creates the publisher backend selected in config
+func New(config *cfg.PublisherConfig) (Publisher, error) {
+	switch config.Backend {
+	case "kafka":
+		return NewKafkaPublisher(config.Brokers), nil
+	case "file":
+		return NewFilePublisher(config.FilePath)
+	case "memory":
+		return NewMemoryPublisher(), nil
+	default:
+		return nil, fmt.Errorf("unknown publisher backend: %s", config.Backend)
+	}
+}
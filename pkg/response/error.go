@@ -2,10 +2,15 @@ package response
 
 import (
 	pkgErrors "github.com/vogiaan/ticketbottle-inventory/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
+// errorInfoDomain identifies this service in google.rpc.ErrorInfo details
+const errorInfoDomain = "inventory.ticketbottle"
+
 func GrpcError(err error) error {
 	switch parsedErr := err.(type) {
 	case *pkgErrors.GRPCError:
@@ -13,8 +18,44 @@ func GrpcError(err error) error {
 		if grpcCode == 0 {
 			grpcCode = codes.InvalidArgument
 		}
-		return status.Error(grpcCode, parsedErr.Error())
+
+		st := status.New(grpcCode, parsedErr.Error())
+
+		details := make([]protoadapt.MessageV1, 0, 2)
+		if parsedErr.Reason != "" {
+			details = append(details, &errdetails.ErrorInfo{
+				Reason:   parsedErr.Reason,
+				Domain:   errorInfoDomain,
+				Metadata: parsedErr.Metadata,
+			})
+		}
+		if len(parsedErr.FieldViolations) > 0 {
+			details = append(details, newBadRequest(parsedErr.FieldViolations))
+		}
+
+		if len(details) == 0 {
+			return st.Err()
+		}
+
+		withDetails, detailErr := st.WithDetails(details...)
+		if detailErr != nil {
+			return st.Err()
+		}
+		return withDetails.Err()
 	default:
 		return status.Error(codes.Internal, "Internal server error")
 	}
 }
+
+func newBadRequest(violations []pkgErrors.FieldViolation) *errdetails.BadRequest {
+	br := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(violations)),
+	}
+	for i, v := range violations {
+		br.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		}
+	}
+	return br
+}
@@ -0,0 +1,74 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSubscriber reads messages with a consumer group and commits offsets after they were handled
+type KafkaSubscriber struct {
+	r           *kafka.Reader
+	maxAttempts int
+}
+
+func NewKafkaSubscriber(brokers []string, groupID string, topics []string, maxAttempts int) *KafkaSubscriber {
+	return &KafkaSubscriber{
+		maxAttempts: maxAttempts,
+		r: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     brokers,
+			GroupID:     groupID,
+			GroupTopics: topics,
+			MinBytes:    1,
+			MaxBytes:    10e6,
+		}),
+	}
+}
+
+func (s *KafkaSubscriber) Subscribe(ctx context.Context, h Handler, failed FailedHandler) error {
+	for {
+		m, err := s.r.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch kafka message: %w", err)
+		}
+
+		if err := handleWithRetry(ctx, h, failed, s.buildMessage(m), s.maxAttempts); err != nil {
+			return nil
+		}
+
+		if err := s.r.CommitMessages(ctx, m); err != nil {
+			return fmt.Errorf("failed to commit kafka message: %w", err)
+		}
+	}
+}
+
+func (s *KafkaSubscriber) Close() error {
+	return s.r.Close()
+}
+
+func (s *KafkaSubscriber) buildMessage(m kafka.Message) Message {
+	headers := make(map[string]string, len(m.Headers))
+	for _, h := range m.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+
+	// Prefer the producer's event ID, it survives a producer retry that writes the event at another offset.
+	// Without it the partition and offset identify the message.
+	id := headers["event_id"]
+	if id == "" {
+		id = fmt.Sprintf("%s/%d/%d", m.Topic, m.Partition, m.Offset)
+	}
+
+	return Message{
+		ID:      id,
+		Topic:   m.Topic,
+		Key:     string(m.Key),
+		Value:   m.Value,
+		Headers: headers,
+	}
+}
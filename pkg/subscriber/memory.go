@@ -0,0 +1,45 @@
+package subscriber
+
+import (
+	"context"
+)
+
+// MemorySubscriber is a local stand-in for a broker, messages are fed with Send
+type MemorySubscriber struct {
+	ch          chan Message
+	maxAttempts int
+}
+
+func NewMemorySubscriber(maxAttempts int) *MemorySubscriber {
+	return &MemorySubscriber{
+		ch:          make(chan Message, 256),
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Send queues a message for the subscribed handler
+func (s *MemorySubscriber) Send(ctx context.Context, msg Message) error {
+	select {
+	case s.ch <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *MemorySubscriber) Subscribe(ctx context.Context, h Handler, failed FailedHandler) error {
+	for {
+		select {
+		case msg := <-s.ch:
+			if err := handleWithRetry(ctx, h, failed, msg, s.maxAttempts); err != nil {
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *MemorySubscriber) Close() error {
+	return nil
+}
@@ -0,0 +1,79 @@
+package subscriber
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cfg "github.com/vogiaan/ticketbottle-inventory/config"
+)
+
+// Message is a single record received from a subscriber backend
+type Message struct {
+	// ID identifies the message across redeliveries, it is only unique within Topic
+	ID      string
+	Topic   string
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+// Handler processes one message. Returning an error makes the subscriber retry the same message.
+type Handler func(ctx context.Context, msg Message) error
+
+// FailedHandler is called with the last error once a message failed the maximum number of attempts.
+// Returning nil acknowledges the message, an error keeps retrying it so it is never lost.
+type FailedHandler func(ctx context.Context, msg Message, err error) error
+
+// Subscriber delivers messages to a handler until ctx is cancelled.
+// A message is acknowledged only after the handler, or the failed handler, returned nil.
+type Subscriber interface {
+	Subscribe(ctx context.Context, h Handler, failed FailedHandler) error
+	Close() error
+}
+
+// New creates the subscriber backend selected in config
+func New(config *cfg.SubscriberConfig) (Subscriber, error) {
+	switch config.Backend {
+	case "kafka":
+		return NewKafkaSubscriber(config.Brokers, config.GroupID, config.Topics, config.MaxAttempts), nil
+	case "memory":
+		return NewMemorySubscriber(config.MaxAttempts), nil
+	default:
+		return nil, fmt.Errorf("unknown subscriber backend: %s", config.Backend)
+	}
+}
+
+const (
+	minRetryBackoff = 100 * time.Millisecond
+	maxRetryBackoff = 10 * time.Second
+)
+
+// handleWithRetry calls h until it succeeds or ctx is cancelled. After maxAttempts failures the message
+// is handed to failed, it is only given up on once failed returned nil, so messages are never skipped.
+func handleWithRetry(ctx context.Context, h Handler, failed FailedHandler, msg Message, maxAttempts int) error {
+	backoff := minRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := h(ctx, msg)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= maxAttempts && failed != nil {
+			if failed(ctx, msg, err) == nil {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHandleWithRetry(t *testing.T) {
+	errHandle := errors.New("handler failed")
+	errRecord := errors.New("record failed")
+
+	tests := []struct {
+		name string
+		// failAttempts is how many times the handler fails before it succeeds
+		failAttempts int
+		// recordFails is how many times the failed handler fails before it succeeds
+		recordFails  int
+		wantAttempts int
+		wantFailed   int
+	}{
+		{name: "succeeds first time", failAttempts: 0, wantAttempts: 1},
+		{name: "succeeds on retry", failAttempts: 1, wantAttempts: 2},
+		{name: "gives up after max attempts", failAttempts: 10, wantAttempts: 3, wantFailed: 1},
+		{name: "keeps retrying until recorded", failAttempts: 10, recordFails: 1, wantAttempts: 4, wantFailed: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts, failedCalls := 0, 0
+			h := func(ctx context.Context, msg Message) error {
+				attempts++
+				if attempts <= tt.failAttempts {
+					return errHandle
+				}
+				return nil
+			}
+			failed := func(ctx context.Context, msg Message, err error) error {
+				failedCalls++
+				if !errors.Is(err, errHandle) {
+					t.Errorf("failed handler got %v, want the handler error", err)
+				}
+				if failedCalls <= tt.recordFails {
+					return errRecord
+				}
+				return nil
+			}
+
+			if err := handleWithRetry(context.Background(), h, failed, Message{ID: "m-1"}, 3); err != nil {
+				t.Fatalf("handleWithRetry() error = %v", err)
+			}
+			if attempts != tt.wantAttempts || failedCalls != tt.wantFailed {
+				t.Errorf("attempts = %d, failed calls = %d, want %d and %d", attempts, failedCalls, tt.wantAttempts, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestHandleWithRetryStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	h := func(ctx context.Context, msg Message) error { return errors.New("handler failed") }
+	if err := handleWithRetry(ctx, h, nil, Message{ID: "m-1"}, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("handleWithRetry() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	cfg "github.com/vogiaan/ticketbottle-inventory/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// Provider owns the tracer provider installed as the global one
+type Provider struct {
+	tp *sdktrace.TracerProvider
+}
+
+// New installs the global tracer provider and the W3C trace context propagator.
+// With the "none" exporter the global provider stays a no-op, incoming trace context is still propagated.
+func New(config *cfg.TracingConfig) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exp sdktrace.SpanExporter
+	var err error
+
+	switch config.Exporter {
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exp, err = otlptracegrpc.New(context.Background(), opts...)
+	case "stdout":
+		exp, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "none":
+		return &Provider{}, nil
+	default:
+		return nil, fmt.Errorf("invalid tracing exporter: %s", config.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", config.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	// Callers decide for their traces, the ratio only applies to traces started here
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return &Provider{tp: tp}, nil
+}
+
+// Shutdown flushes the spans still buffered by the exporter
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.tp == nil {
+		return nil
+	}
+
+	if err := p.tp.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown tracer provider: %w", err)
+	}
+	return nil
+}